@@ -0,0 +1,8 @@
+data "ogo_shield_brain_parameters" "all" {}
+
+# Map brain parameter human names to their override key
+locals {
+  brain_parameters = {
+    for p in data.ogo_shield_brain_parameters.all.parameters : p.name => p.key
+  }
+}
@@ -0,0 +1,19 @@
+# All countries which can be blacklisted
+data "ogo_shield_countries" "all" {}
+
+# Countries of the European Economic Area
+data "ogo_shield_countries" "eea" {
+  regions = ["EEA"]
+}
+
+# Blacklist sanctioned countries on a site
+data "ogo_shield_countries" "sanctioned" {
+  regions = ["SANCTIONED"]
+}
+
+resource "ogo_shield_site" "foo_example_com" {
+  domain_name           = "foo.example.com"
+  cluster_uid           = var.cluster_uid
+  origin_server         = "172.18.1.10"
+  blacklisted_countries = data.ogo_shield_countries.sanctioned.codes
+}
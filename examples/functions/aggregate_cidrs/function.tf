@@ -0,0 +1,19 @@
+# Merge several allowlists into the smallest list of CIDR ranges
+locals {
+  allowed_ips = provider::ogo::aggregate_cidrs(concat(
+    provider::ogo::parse_ip_list(file("${path.module}/partners.txt")),
+    ["192.0.2.0/25", "192.0.2.128/25"],
+  ))
+}
+
+resource "ogo_shield_site" "partners_example_com" {
+  domain_name   = "partners.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.14"
+  rules = [
+    {
+      paths           = ["/partners"]
+      whitelisted_ips = local.allowed_ips
+    },
+  ]
+}
@@ -0,0 +1,22 @@
+# Simulate a request against site rules
+output "admin_from_office" {
+  value = provider::ogo::match_rule(ogo_shield_site.bar_example_com.rules, "/admin/login", "10.10.9.42")
+}
+
+# Ensure that admin pages are only reachable from office networks
+check "admin_restricted" {
+  assert {
+    condition     = !provider::ogo::match_rule(ogo_shield_site.bar_example_com.rules, "/admin", "198.51.100.1").allowed
+    error_message = "/admin must not be reachable outside of office networks."
+  }
+}
+
+# Rules can also be given as a literal list, with only paths required
+output "literal_rules" {
+  value = provider::ogo::match_rule([
+    {
+      paths           = ["/admin", "/wp-admin"]
+      whitelisted_ips = ["10.10.9.0/24", "fded:b552:6f7e:fc6f::/64"]
+    }
+  ], "/wp-admin/", "fded:b552:6f7e:fc6f::1")
+}
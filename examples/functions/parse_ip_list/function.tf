@@ -0,0 +1,22 @@
+# Turn a text allowlist, with comments and address ranges, into site IP exceptions
+resource "ogo_shield_site" "partners_example_com" {
+  domain_name   = "partners.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.14"
+  ip_exceptions = [
+    for ip in provider::ogo::parse_ip_list(file("${path.module}/partners.txt")) : {
+      ip      = ip
+      comment = "Partners"
+    }
+  ]
+}
+
+# Literal lists can also be parsed
+output "parsed_ip_list" {
+  value = provider::ogo::parse_ip_list(<<-EOT
+    # Paris office
+    192.0.2.0/24
+    198.51.100.8-198.51.100.15 # VPN
+  EOT
+  )
+}
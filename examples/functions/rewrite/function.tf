@@ -0,0 +1,22 @@
+# Simulate rewriting of a request path by site rewrite rules
+output "rewritten_path" {
+  value = provider::ogo::rewrite(ogo_shield_site.bar_example_com.rewrite_rules, "/old/index.html")
+}
+
+# Ensure that old paths are rewritten to new ones
+check "old_paths_rewritten" {
+  assert {
+    condition     = provider::ogo::rewrite(ogo_shield_site.bar_example_com.rewrite_rules, "/old/index.html") == "/new/index.html"
+    error_message = "/old must be rewritten to /new."
+  }
+}
+
+# Rewrite rules can also be given as a literal list, with submatch expansion
+output "literal_rewrite_rules" {
+  value = provider::ogo::rewrite([
+    {
+      rewrite_source      = "^/api/v1/(.*)"
+      rewrite_destination = "/api/v2/$1"
+    }
+  ], "/api/v1/users")
+}
@@ -0,0 +1,17 @@
+# List all sites of the organization with terraform query (Terraform 1.14+)
+list "ogo_shield_site" "all" {
+  provider = ogo
+}
+
+# List offline sites of a cluster with given tags, including full site
+# configuration to generate with -generate-config-out
+list "ogo_shield_site" "offline" {
+  provider         = ogo
+  include_resource = true
+
+  config {
+    cluster_uid = "a1b2c3d4e5"
+    status      = ["OFFLINE"]
+    tags        = ["production"]
+  }
+}
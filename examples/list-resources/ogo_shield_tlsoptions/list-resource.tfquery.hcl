@@ -0,0 +1,5 @@
+# List all TLS options of the organization with terraform query (Terraform 1.14+)
+list "ogo_shield_tlsoptions" "all" {
+  provider         = ogo
+  include_resource = true
+}
@@ -0,0 +1,8 @@
+# Import foo.ogosecurity.com existing site using its resource identity (Terraform 1.12+)
+import {
+  to = ogo_shield_site.foo_ogosecurity_com
+  identity = {
+    organization = "myorganization"
+    domain_name  = "foo.ogosecurity.com"
+  }
+}
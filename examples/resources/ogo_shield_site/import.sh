@@ -1,2 +1,4 @@
 # Import foo.ogosecurity.com existing site from OGO Dashboard to resource foo_ogosecurity_com in terraform state
 terraform import ogo_shield_site.foo_ogosecurity_com foo.ogosecurity.com
+# Import ID can be prefixed with organization code, which must match provider organization
+terraform import ogo_shield_site.foo_ogosecurity_com myorganization/foo.ogosecurity.com
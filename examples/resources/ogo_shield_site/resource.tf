@@ -83,3 +83,77 @@ resource "ogo_shield_site" "bar_example_com" {
     },
   ]
 }
+
+# Wait for site to be protected by a TLS certificate and CDN to be active
+resource "ogo_shield_site" "cdn_example_com" {
+  domain_name   = "cdn.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.12"
+  cdn           = "ORANGE"
+  wait_for = {
+    status     = ["LE_CERT", "CUST_CERT"]
+    cdn_status = ["ACTIVE"]
+  }
+  timeouts {
+    create = "45m"
+  }
+}
+
+# DNS records to be configured for site domain name to redirect to Ogo Shield cluster
+output "foo_example_com_dns_records" {
+  value = ogo_shield_site.foo_example_com.dns_records
+}
+
+# Production site which can't be deleted or replaced by mistake
+resource "ogo_shield_site" "www_example_com" {
+  domain_name         = "www.example.com"
+  cluster_uid         = var.cluster_uid
+  origin_server       = "172.18.1.13"
+  deletion_protection = true
+}
+
+# Partner allowlist maintained in a text file, site is updated when file content changes
+resource "ogo_shield_site" "partners_example_com" {
+  domain_name   = "partners.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.14"
+  ip_exceptions_source = {
+    file    = "${path.module}/partners.txt"
+    comment = "Partners"
+  }
+}
+
+# Rules applied by priority, new rules can be appended whatever their order of application
+resource "ogo_shield_site" "api_example_com" {
+  domain_name   = "api.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.15"
+  rules = [
+    {
+      priority        = 20
+      comment         = "API"
+      paths           = ["/api"]
+      whitelisted_ips = []
+    },
+    {
+      priority        = 10
+      comment         = "Admin API from office"
+      paths           = ["/api/admin"]
+      whitelisted_ips = ["10.10.9.0/24"]
+    },
+  ]
+}
+
+# Temporary IP exception, removed from the site once expired
+resource "ogo_shield_site" "scan_example_com" {
+  domain_name   = "scan.example.com"
+  cluster_uid   = var.cluster_uid
+  origin_server = "172.18.1.16"
+  ip_exceptions = [
+    {
+      ip         = "192.0.2.10"
+      comment    = "Partner scanner"
+      expires_at = "2025-12-31T23:59:59Z"
+    },
+  ]
+}
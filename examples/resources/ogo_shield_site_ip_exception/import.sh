@@ -0,0 +1,2 @@
+# Import IP exception 192.0.2.0/24 of foo.ogosecurity.com site, ID format is <domain_name>/<ip>
+terraform import ogo_shield_site_ip_exception.office foo.ogosecurity.com/192.0.2.0/24
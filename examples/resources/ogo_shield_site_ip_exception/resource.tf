@@ -0,0 +1,14 @@
+# Manage site exceptions and rules with dedicated resources
+resource "ogo_shield_site" "foo_ogosecurity_com" {
+  domain_name                 = "foo.ogosecurity.com"
+  cluster_uid                 = "abcd"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+# Never block requests from office network
+resource "ogo_shield_site_ip_exception" "office" {
+  domain_name = ogo_shield_site.foo_ogosecurity_com.domain_name
+  ip          = "192.0.2.0/24"
+  comment     = "Office"
+}
@@ -0,0 +1,2 @@
+# Import rewrite rule ^/api/v1/(.*) of foo.ogosecurity.com site, ID format is <domain_name>/<rewrite_source>
+terraform import ogo_shield_site_rewrite_rule.api 'foo.ogosecurity.com/^/api/v1/(.*)'
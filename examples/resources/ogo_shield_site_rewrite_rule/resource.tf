@@ -0,0 +1,15 @@
+# Manage site exceptions and rules with dedicated resources
+resource "ogo_shield_site" "foo_ogosecurity_com" {
+  domain_name                 = "foo.ogosecurity.com"
+  cluster_uid                 = "abcd"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+# Rewrite API v1 requests to API v2
+resource "ogo_shield_site_rewrite_rule" "api" {
+  domain_name         = ogo_shield_site.foo_ogosecurity_com.domain_name
+  comment             = "API v1 to v2"
+  rewrite_source      = "^/api/v1/(.*)"
+  rewrite_destination = "/api/v2/$1"
+}
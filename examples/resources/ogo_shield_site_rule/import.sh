@@ -0,0 +1,2 @@
+# Import rule matching /admin and /wp-admin paths of foo.ogosecurity.com site, ID format is <domain_name>/<path>[,<path>...]
+terraform import ogo_shield_site_rule.admin foo.ogosecurity.com//admin,/wp-admin
@@ -0,0 +1,15 @@
+# Manage site exceptions and rules with dedicated resources
+resource "ogo_shield_site" "foo_ogosecurity_com" {
+  domain_name                 = "foo.ogosecurity.com"
+  cluster_uid                 = "abcd"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+# Restrict admin pages to office network
+resource "ogo_shield_site_rule" "admin" {
+  domain_name     = ogo_shield_site.foo_ogosecurity_com.domain_name
+  comment         = "Admin access"
+  paths           = ["/admin", "/wp-admin"]
+  whitelisted_ips = ["192.0.2.0/24"]
+}
@@ -0,0 +1,2 @@
+# Import URL exception /healthz of foo.ogosecurity.com site, ID format is <domain_name>/<path>
+terraform import ogo_shield_site_url_exception.healthz foo.ogosecurity.com//healthz
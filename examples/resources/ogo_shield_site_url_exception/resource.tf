@@ -0,0 +1,14 @@
+# Manage site exceptions and rules with dedicated resources
+resource "ogo_shield_site" "foo_ogosecurity_com" {
+  domain_name                 = "foo.ogosecurity.com"
+  cluster_uid                 = "abcd"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+# Never block requests to health check path
+resource "ogo_shield_site_url_exception" "healthz" {
+  domain_name = ogo_shield_site.foo_ogosecurity_com.domain_name
+  path        = "/healthz"
+  comment     = "Health check"
+}
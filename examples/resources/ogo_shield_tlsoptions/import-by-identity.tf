@@ -0,0 +1,8 @@
+# Import TLS options using their resource identity (Terraform 1.12+)
+import {
+  to = ogo_shield_tlsoptions.mtls_foobar
+  identity = {
+    organization = "myorganization"
+    uid          = "example00812-f4d2574e-d85e-5dg7-ad11-1edd0489jmp1"
+  }
+}
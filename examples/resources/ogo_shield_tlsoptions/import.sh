@@ -1,3 +1,5 @@
 # Import 'mTLS foo bar' TLS options reference by UID example00812-f4d2574e-d85e-5dg7-ad11-1edd0489jmp1 from OGO Dashboard to resource mtls_foobar in terraform state
 # Note: TLS options UID can be retrieved from data source
 terraform import ogo_shield_tlsoptions.mtls_foobar example00812-f4d2574e-d85e-5dg7-ad11-1edd0489jmp1
+# Import ID can be prefixed with organization code, which must match provider organization
+terraform import ogo_shield_tlsoptions.mtls_foobar myorganization/example00812-f4d2574e-d85e-5dg7-ad11-1edd0489jmp1
@@ -55,3 +55,19 @@ kjHKjCnt0/9fttE1u/gMW7k=
 EOT
   ]
 }
+
+# Restrict cipher suites and curves, and reject clients without SNI
+resource "ogo_shield_tlsoptions" "pci" {
+  name                 = "PCI"
+  min_tls_version      = "TLS_1.2"
+  client_auth_ca_certs = []
+  cipher_suites = [
+    "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+    "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+    "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+    "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+  ]
+  curve_preferences = ["X25519", "CurveP256"]
+  sni_strict        = true
+  alpn_protocols    = ["h2", "http/1.1"]
+}
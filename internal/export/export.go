@@ -0,0 +1,349 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package export generates Terraform configuration of an existing
+// organization, with import blocks to bring its sites and TLS options under
+// Terraform management.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Exported configuration file names.
+const (
+	tlsOptionsFile = "tlsoptions.tf"
+	sitesFile      = "sites.tf"
+)
+
+// Options defines how configuration is exported.
+type Options struct {
+	// SplitByTag writes sites in one file per tag, sites_<tag>.tf, instead
+	// of a single sites.tf file. Sites with several tags are written in the
+	// file of their first tag in alphabetical order, sites without tag are
+	// written in sites.tf.
+	SplitByTag bool
+}
+
+// Generate returns Terraform configuration files content of all sites and
+// TLS options of client organization, indexed by file name.
+func Generate(client *ogosecurity.Client, options Options) (map[string][]byte, error) {
+	allTlsOptions, err := ogosecurity.Collect(client.AllTlsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("could not list TLS options: %w", err)
+	}
+
+	sites, err := ogosecurity.Collect(client.AllSites())
+	if err != nil {
+		return nil, fmt.Errorf("could not list sites: %w", err)
+	}
+
+	files := map[string]*hclwrite.File{}
+	file := func(name string) *hclwrite.Body {
+		if _, ok := files[name]; !ok {
+			files[name] = hclwrite.NewEmptyFile()
+		}
+		return files[name].Body()
+	}
+
+	// TLS options, sites reference them by resource name
+	tlsOptionsNames := map[string]string{}
+	tlsOptionsUsed := names{}
+	for _, tlsOptions := range allTlsOptions {
+		name := tlsOptionsUsed.name(tlsOptions.Name, "tlsoptions")
+		tlsOptionsNames[tlsOptions.Uid] = name
+		writeTlsOptions(file(tlsOptionsFile), client.Organization, name, &tlsOptions)
+	}
+
+	// Sites
+	sitesUsed := names{}
+	for _, site := range sites {
+		name := sitesUsed.name(site.DomainName, "site")
+		writeSite(file(siteFile(&site, options)), client.Organization, name, &site, tlsOptionsNames)
+	}
+
+	content := map[string][]byte{}
+	for name, f := range files {
+		content[name] = hclwrite.Format(f.Bytes())
+	}
+
+	return content, nil
+}
+
+// Write writes files content in dir, existing files are only overwritten
+// if overwrite is true. It returns the paths of written files.
+func Write(dir string, files map[string][]byte, overwrite bool) ([]string, error) {
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	paths := []string{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				return paths, fmt.Errorf("file %s already exists", path)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return paths, err
+			}
+		}
+
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// siteFile returns the name of the file where site is written.
+func siteFile(site *ogosecurity.Site, options Options) string {
+	if !options.SplitByTag || len(site.Tags) == 0 {
+		return sitesFile
+	}
+
+	tags := append([]string{}, site.Tags...)
+	sort.Strings(tags)
+
+	return "sites_" + sanitize(tags[0]) + ".tf"
+}
+
+// writeTlsOptions appends TLS options resource and import blocks to body.
+func writeTlsOptions(body *hclwrite.Body, organization string, name string, tlsOptions *ogosecurity.TlsOptions) {
+	block := body.AppendNewBlock("resource", []string{"ogo_shield_tlsoptions", name}).Body()
+	block.SetAttributeValue("name", cty.StringVal(tlsOptions.Name))
+	if tlsOptions.ClientAuthType != "" && tlsOptions.ClientAuthType != "VerifyClientCertIfGiven" {
+		block.SetAttributeValue("client_auth_type", cty.StringVal(tlsOptions.ClientAuthType))
+	}
+	if tlsOptions.MinTlsVersion != nil && *tlsOptions.MinTlsVersion != "" && *tlsOptions.MinTlsVersion != "TLS_1.2" {
+		block.SetAttributeValue("min_tls_version", cty.StringVal(*tlsOptions.MinTlsVersion))
+	}
+	if tlsOptions.MaxTlsVersion != nil && *tlsOptions.MaxTlsVersion != "" {
+		block.SetAttributeValue("max_tls_version", cty.StringVal(*tlsOptions.MaxTlsVersion))
+	}
+	block.SetAttributeRaw("client_auth_ca_certs", stringListTokens(tlsOptions.ClientAuthCaCerts))
+	if len(tlsOptions.CipherSuites) > 0 {
+		block.SetAttributeRaw("cipher_suites", stringListTokens(tlsOptions.CipherSuites))
+	}
+	if len(tlsOptions.CurvePreferences) > 0 {
+		block.SetAttributeRaw("curve_preferences", stringListTokens(tlsOptions.CurvePreferences))
+	}
+	if tlsOptions.SniStrict != nil && *tlsOptions.SniStrict {
+		block.SetAttributeValue("sni_strict", cty.True)
+	}
+	if len(tlsOptions.AlpnProtocols) > 0 {
+		block.SetAttributeRaw("alpn_protocols", stringListTokens(tlsOptions.AlpnProtocols))
+	}
+
+	writeImport(body, "ogo_shield_tlsoptions", name, organization+"/"+tlsOptions.Uid)
+}
+
+// writeSite appends site resource and import blocks to body. TLS options
+// exported with the site are referenced by their resource name.
+func writeSite(body *hclwrite.Body, organization string, name string, site *ogosecurity.Site, tlsOptionsNames map[string]string) {
+	block := body.AppendNewBlock("resource", []string{"ogo_shield_site", name}).Body()
+	block.SetAttributeValue("domain_name", cty.StringVal(site.DomainName))
+	block.SetAttributeValue("cluster_uid", cty.StringVal(site.Cluster.Uid))
+	if site.Contract != nil && site.Contract.Number != "" {
+		block.SetAttributeValue("contract_number", cty.StringVal(site.Contract.Number))
+	}
+	block.SetAttributeValue("origin_server", cty.StringVal(site.OriginServer))
+	if site.OriginScheme != "" && site.OriginScheme != "https" {
+		block.SetAttributeValue("origin_scheme", cty.StringVal(site.OriginScheme))
+	}
+	if site.OriginPort != nil && *site.OriginPort != ogosecurity.OriginDefaultPort(site.OriginScheme) {
+		block.SetAttributeValue("origin_port", cty.NumberIntVal(int64(*site.OriginPort)))
+	}
+
+	// Settings, only written if different from default value
+	for _, setting := range []struct {
+		name  string
+		value bool
+	}{
+		{"origin_mtls_enabled", site.OriginMtlsEnabled},
+		{"origin_skip_cert_verify", site.OriginSkipCertVerify},
+		{"remove_xforwarded", site.RemoveXForwarded},
+		{"force_https", site.ForceHttps},
+		{"audit_mode", site.AuditMode},
+		{"passthrough_mode", site.PassthroughMode},
+		{"log_export_enabled", site.LogExportEnabled},
+		{"cache_enabled", site.CacheEnabled},
+	} {
+		if setting.value {
+			block.SetAttributeValue(setting.name, cty.True)
+		}
+	}
+	if site.Hsts != "" && site.Hsts != "hsts" {
+		block.SetAttributeValue("hsts", cty.StringVal(site.Hsts))
+	}
+	if site.PassTlsClientCert != "" && site.PassTlsClientCert != "info" {
+		block.SetAttributeValue("pass_tls_client_cert", cty.StringVal(site.PassTlsClientCert))
+	}
+	if site.Cdn != nil && *site.Cdn != "" {
+		block.SetAttributeValue("cdn", cty.StringVal(*site.Cdn))
+	}
+
+	// TLS options
+	if site.TlsOptions != nil && site.TlsOptions.Uid != "" {
+		if tlsOptionsName, ok := tlsOptionsNames[site.TlsOptions.Uid]; ok {
+			block.SetAttributeTraversal("tlsoptions_uid", hcl.Traversal{
+				hcl.TraverseRoot{Name: "ogo_shield_tlsoptions"},
+				hcl.TraverseAttr{Name: tlsOptionsName},
+				hcl.TraverseAttr{Name: "uid"},
+			})
+		} else {
+			block.SetAttributeValue("tlsoptions_uid", cty.StringVal(site.TlsOptions.Uid))
+		}
+	}
+
+	if len(site.BlacklistedCountries) > 0 {
+		block.SetAttributeValue("blacklisted_countries", stringList(site.BlacklistedCountries))
+	}
+	if len(site.BrainOverrides) > 0 {
+		overrides := map[string]cty.Value{}
+		for key, value := range site.BrainOverrides {
+			overrides[key] = cty.NumberFloatVal(value)
+		}
+		block.SetAttributeValue("brain_overrides", cty.ObjectVal(overrides))
+	}
+
+	// Exceptions and rules
+	if len(site.IpExceptions) > 0 {
+		objects := []attributes{}
+		for _, e := range site.IpExceptions {
+			objects = append(objects, attributes{
+				{"ip", cty.StringVal(e.Ip)},
+			}.withComment(e.Comment))
+		}
+		block.SetAttributeRaw("ip_exceptions", objectListTokens(objects))
+	}
+	if len(site.UrlExceptions) > 0 {
+		objects := []attributes{}
+		for _, e := range site.UrlExceptions {
+			objects = append(objects, attributes{
+				{"path", cty.StringVal(e.Path)},
+			}.withComment(e.Comment))
+		}
+		block.SetAttributeRaw("url_exceptions", objectListTokens(objects))
+	}
+	if len(site.RewriteRules) > 0 {
+		objects := []attributes{}
+		for _, rule := range site.RewriteRules {
+			object := attributes{
+				{"rewrite_source", cty.StringVal(rule.RewriteSource)},
+				{"rewrite_destination", cty.StringVal(rule.RewriteDestination)},
+			}
+			if !rule.Active {
+				object = append(object, attribute{"active", cty.False})
+			}
+			objects = append(objects, object.withComment(rule.Comment))
+		}
+		block.SetAttributeRaw("rewrite_rules", objectListTokens(objects))
+	}
+	if len(site.Rules) > 0 {
+		objects := []attributes{}
+		for _, rule := range site.Rules {
+			object := attributes{
+				{"paths", stringList(rule.Paths)},
+				{"whitelisted_ips", stringList(rule.WhitelistedIps)},
+			}
+			if rule.Action != "" && rule.Action != "brain" {
+				object = append(object, attribute{"action", cty.StringVal(rule.Action)})
+			}
+			if rule.Cache {
+				object = append(object, attribute{"cache", cty.True})
+			}
+			if !rule.Active {
+				object = append(object, attribute{"active", cty.False})
+			}
+			objects = append(objects, object.withComment(rule.Comment))
+		}
+		block.SetAttributeRaw("rules", objectListTokens(objects))
+	}
+
+	if len(site.Tags) > 0 {
+		block.SetAttributeValue("tags", stringList(site.Tags))
+	}
+
+	writeImport(body, "ogo_shield_site", name, organization+"/"+site.DomainName)
+}
+
+// writeImport appends an import block of resource to body.
+func writeImport(body *hclwrite.Body, resourceType string, name string, id string) {
+	block := body.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	block.SetAttributeValue("id", cty.StringVal(id))
+	body.AppendNewline()
+}
+
+// stringList returns a list value of strings.
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	list := []cty.Value{}
+	for _, value := range values {
+		list = append(list, cty.StringVal(value))
+	}
+
+	return cty.ListVal(list)
+}
+
+// names generates unique Terraform resource names.
+type names map[string]bool
+
+// name returns a unique resource name derived from value, prefixed if it
+// doesn't start with a letter.
+func (n names) name(value string, prefix string) string {
+	base := sanitize(value)
+	if base == "" || !(base[0] >= 'a' && base[0] <= 'z') {
+		base = strings.TrimSuffix(prefix+"_"+base, "_")
+	}
+
+	name := base
+	for i := 2; n[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[name] = true
+
+	return name
+}
+
+// sanitize returns value in lower case with any sequence of characters
+// other than letters and digits replaced by an underscore.
+func sanitize(value string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+
+	return b.String()
+}
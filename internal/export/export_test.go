@@ -0,0 +1,169 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const testTlsOptions = `{
+  "content": [
+    {
+      "uid": "b1f3c2d4",
+      "name": "mTLS foo",
+      "clientAuthType": "RequireAndVerifyClientCert",
+      "clientAuthCaCerts": ["-----BEGIN CERTIFICATE-----\nMIIDnTCCAoWgAwIBAgIU\n-----END CERTIFICATE-----\n"],
+      "minTlsVersion": "TLS_1.2",
+      "maxTlsVersion": "TLS_1.3",
+      "cipherSuites": ["TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"],
+      "sniStrict": true
+    }
+  ],
+  "totalElements": 1
+}`
+
+const testSitesPage0 = `{
+  "content": [
+    {
+      "domainName": "foo.example.com",
+      "cluster": {"clusterId": "c1"},
+      "originServer": "172.18.1.10",
+      "originScheme": "https",
+      "originPort": 443,
+      "hsts": "hsts",
+      "passTlsClientCert": "info",
+      "tlsOptions": {"uid": "b1f3c2d4"},
+      "ipExceptions": [{"ip": "192.0.2.0/24", "comment": "office"}],
+      "rules": [{"active": true, "action": "brain", "paths": ["/admin"], "whitelistedIps": ["10.10.9.0/24"]}],
+      "tags": ["prod", "api"]
+    }
+  ],
+  "totalElements": 2
+}`
+
+const testSitesPage1 = `{
+  "content": [
+    {
+      "domainName": "2.example.com",
+      "cluster": {"clusterId": "c1"},
+      "originServer": "172.18.1.11",
+      "originScheme": "http",
+      "originPort": 8080,
+      "forceHttps": true,
+      "tlsOptions": {"uid": "unknown"},
+      "rules": [{"active": true, "action": "brain", "paths": ["/private"], "whitelistedIps": []}]
+    }
+  ],
+  "totalElements": 2
+}`
+
+// spaces matches alignment spaces of formatted configuration.
+var spaces = regexp.MustCompile(` +`)
+
+func testClient(t *testing.T) *ogosecurity.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/organizations/org/tls-options":
+			_, _ = w.Write([]byte(testTlsOptions))
+		case r.URL.Path == "/v2/organizations/org/sites" && r.URL.Query().Get("page") == "0":
+			_, _ = w.Write([]byte(testSitesPage0))
+		case r.URL.Path == "/v2/organizations/org/sites" && r.URL.Query().Get("page") == "1":
+			_, _ = w.Write([]byte(testSitesPage1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, email, apikey, organization := server.URL, "user@example.com", "key", "org"
+	client, err := ogosecurity.NewClient(&endpoint, &email, &apikey, &organization)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return client
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := Generate(testClient(t), Options{})
+	if err != nil {
+		t.Fatalf("unexpected error generating configuration: %s", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	for name, content := range files {
+		if _, diags := hclsyntax.ParseConfig(content, name, hcl.InitialPos); diags.HasErrors() {
+			t.Errorf("invalid configuration in %s: %s\n%s", name, diags.Error(), content)
+		}
+	}
+
+	for name, expected := range map[string][]string{
+		"tlsoptions.tf": {
+			`resource "ogo_shield_tlsoptions" "mtls_foo" {`,
+			`client_auth_type     = "RequireAndVerifyClientCert"`,
+			`max_tls_version      = "TLS_1.3"`,
+			"<<EOT\n-----BEGIN CERTIFICATE-----\nMIIDnTCCAoWgAwIBAgIU\n-----END CERTIFICATE-----\nEOT",
+			"cipher_suites = [\n    \"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256\",\n    \"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256\",\n  ]",
+			`sni_strict = true`,
+			"to = ogo_shield_tlsoptions.mtls_foo\n  id = \"org/b1f3c2d4\"",
+		},
+		"sites.tf": {
+			`resource "ogo_shield_site" "foo_example_com" {`,
+			`tlsoptions_uid = ogo_shield_tlsoptions.mtls_foo.uid`,
+			"ip      = \"192.0.2.0/24\"\n      comment = \"office\"",
+			`whitelisted_ips = ["10.10.9.0/24"]`,
+			`tags           = ["prod", "api"]`,
+			"to = ogo_shield_site.foo_example_com\n  id = \"org/foo.example.com\"",
+			`resource "ogo_shield_site" "site_2_example_com" {`,
+			`origin_scheme  = "http"`,
+			`origin_port    = 8080`,
+			`force_https    = true`,
+			`tlsoptions_uid = "unknown"`,
+			"paths           = [\"/private\"]\n      whitelisted_ips = []",
+		},
+	} {
+		content := spaces.ReplaceAllString(string(files[name]), " ")
+		for _, e := range expected {
+			if !strings.Contains(content, spaces.ReplaceAllString(e, " ")) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, e, content)
+			}
+		}
+	}
+
+	for _, unexpected := range []string{"443", "hsts", "pass_tls_client_cert", "action"} {
+		if strings.Contains(string(files["sites.tf"]), unexpected) {
+			t.Errorf("expected default value %q not to be written, got:\n%s", unexpected, files["sites.tf"])
+		}
+	}
+}
+
+func TestGenerateSplitByTag(t *testing.T) {
+	files, err := Generate(testClient(t), Options{SplitByTag: true})
+	if err != nil {
+		t.Fatalf("unexpected error generating configuration: %s", err)
+	}
+
+	for name, domainName := range map[string]string{
+		"sites_api.tf": "foo.example.com",
+		"sites.tf":     "2.example.com",
+	} {
+		if !strings.Contains(spaces.ReplaceAllString(string(files[name]), " "), `domain_name = "`+domainName+`"`) {
+			t.Errorf("expected %s to contain site %s, got:\n%s", name, domainName, files[name])
+		}
+	}
+}
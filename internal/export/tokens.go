@@ -0,0 +1,89 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package export
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// attribute is an object attribute written in order.
+type attribute struct {
+	name  string
+	value cty.Value
+}
+
+// attributes are object attributes written in order.
+type attributes []attribute
+
+// withComment returns attributes with comment appended, if not empty.
+func (a attributes) withComment(comment string) attributes {
+	if comment == "" {
+		return a
+	}
+	return append(a, attribute{"comment", cty.StringVal(comment)})
+}
+
+// objectListTokens returns tokens of a list of objects, written with one
+// object attribute per line.
+func objectListTokens(objects []attributes) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{token(hclsyntax.TokenOBrack, "["), newline()}
+	for _, object := range objects {
+		tokens = append(tokens, token(hclsyntax.TokenOBrace, "{"), newline())
+		for _, a := range object {
+			tokens = append(tokens, token(hclsyntax.TokenIdent, a.name), token(hclsyntax.TokenEqual, "="))
+			tokens = append(tokens, hclwrite.TokensForValue(a.value)...)
+			tokens = append(tokens, newline())
+		}
+		tokens = append(tokens, token(hclsyntax.TokenCBrace, "}"), token(hclsyntax.TokenComma, ","), newline())
+	}
+
+	return append(tokens, token(hclsyntax.TokenCBrack, "]"))
+}
+
+// stringListTokens returns tokens of a list of strings, written with one
+// string per line. Multiline strings, like PEM certificates, are written as
+// heredoc.
+func stringListTokens(values []string) hclwrite.Tokens {
+	if len(values) == 0 {
+		return hclwrite.Tokens{token(hclsyntax.TokenOBrack, "["), token(hclsyntax.TokenCBrack, "]")}
+	}
+
+	tokens := hclwrite.Tokens{token(hclsyntax.TokenOBrack, "["), newline()}
+	for _, value := range values {
+		tokens = append(tokens, stringTokens(value)...)
+		tokens = append(tokens, token(hclsyntax.TokenComma, ","), newline())
+	}
+
+	return append(tokens, token(hclsyntax.TokenCBrack, "]"))
+}
+
+// stringTokens returns tokens of a string, written as heredoc if it spans
+// multiple lines and doesn't need escaping.
+func stringTokens(value string) hclwrite.Tokens {
+	if !strings.Contains(strings.TrimSuffix(value, "\n"), "\n") || !strings.HasSuffix(value, "\n") ||
+		strings.Contains(value, "${") || strings.Contains(value, "%{") || strings.Contains(value, "\nEOT\n") {
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	}
+
+	return hclwrite.Tokens{
+		token(hclsyntax.TokenOHeredoc, "<<EOT\n"),
+		token(hclsyntax.TokenStringLit, value),
+		token(hclsyntax.TokenCHeredoc, "EOT"),
+		newline(),
+	}
+}
+
+// token returns a token of type with bytes.
+func token(tokenType hclsyntax.TokenType, bytes string) *hclwrite.Token {
+	return &hclwrite.Token{Type: tokenType, Bytes: []byte(bytes)}
+}
+
+// newline returns a newline token.
+func newline() *hclwrite.Token {
+	return token(hclsyntax.TokenNewline, "\n")
+}
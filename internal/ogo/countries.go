@@ -0,0 +1,31 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ogosecurity
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Returns all countries which can be blacklisted on a site.
+func (c *Client) GetAllCountries() ([]BlacklistedCountry, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/countries", c.HostBaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []BlacklistedCountry
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
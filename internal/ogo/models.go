@@ -35,11 +35,36 @@ type Site struct {
 	Tags                      []string                   `json:"tags"`
 }
 
+// Sites page.
+type SitesResponse struct {
+	Sites []Site `json:"content"`
+	Count int    `json:"totalElements"`
+}
+
+// Site partial update object, only non nil attributes are updated.
+type SitePatch struct {
+	IpExceptions  *[]IpException  `json:"ipExceptions,omitempty"`
+	UrlExceptions *[]UrlException `json:"urlExceptions,omitempty"`
+	RewriteRules  *[]RewriteRule  `json:"rewriteRules,omitempty"`
+	Rules         *[]Rule         `json:"rules,omitempty"`
+	// TLS options are removed from site if TlsOptions points to nil.
+	TlsOptions **TlsOptions `json:"tlsOptions,omitempty"`
+}
+
 type BlacklistedCountry struct {
 	CountryCode string `json:"countryCode"`
 	CountryName string `json:"countryNameEn"`
 }
 
+type BrainParameter struct {
+	Key          string   `json:"key"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	DefaultValue float64  `json:"defaultValue"`
+	MinValue     *float64 `json:"minValue"`
+	MaxValue     *float64 `json:"maxValue"`
+}
+
 type UrlException struct {
 	Path    string `json:"path"`
 	Comment string `json:"comment"`
@@ -73,6 +98,10 @@ type TlsOptions struct {
 	ClientAuthCaCerts []string `json:"clientAuthCaCerts,omitempty"`
 	MinTlsVersion     *string  `json:"minTlsVersion,omitempty"`
 	MaxTlsVersion     *string  `json:"maxTlsVersion,omitempty"`
+	CipherSuites      []string `json:"cipherSuites,omitempty"`
+	CurvePreferences  []string `json:"curvePreferences,omitempty"`
+	SniStrict         *bool    `json:"sniStrict,omitempty"`
+	AlpnProtocols     []string `json:"alpnProtocols,omitempty"`
 	Uid               string   `json:"uid,omitempty"`
 }
 
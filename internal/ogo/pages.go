@@ -0,0 +1,51 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ogosecurity
+
+import (
+	"iter"
+)
+
+// Number of objects fetched per page when listing all objects.
+const ListPageSize = 100
+
+// Returns an iterator over objects of all pages returned by fetch. fetch
+// returns objects of a page and the total count of objects, iteration stops
+// at the first error.
+func listAll[T any](fetch func(page int, size int) ([]T, int, error)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		fetched := 0
+		for page := 0; ; page++ {
+			objects, count, err := fetch(page, ListPageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range objects {
+				if !yield(&objects[i], nil) {
+					return
+				}
+			}
+
+			fetched += len(objects)
+			if len(objects) == 0 || fetched >= count {
+				return
+			}
+		}
+	}
+}
+
+// Returns all objects of an iterator, or its first error.
+func Collect[T any](objects iter.Seq2[*T, error]) ([]T, error) {
+	all := []T{}
+	for object, err := range objects {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *object)
+	}
+
+	return all, nil
+}
@@ -6,10 +6,52 @@ package ogosecurity
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 )
 
+// Returns the standard port of an origin scheme.
+func OriginDefaultPort(scheme string) int32 {
+	if scheme == "http" {
+		return 80
+	}
+
+	return 443
+}
+
+// Returns a page of user's sites, pages are numbered from 0.
+func (c *Client) ListSites(page int, size int) (*SitesResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/sites?page=%d&size=%d", c.HostBaseURL, page, size), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := SitesResponse{}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Returns an iterator over user's sites of all pages.
+func (c *Client) AllSites() iter.Seq2[*Site, error] {
+	return listAll(func(page int, size int) ([]Site, int, error) {
+		resp, err := c.ListSites(page, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Sites, resp.Count, nil
+	})
+}
+
 // Returns a specifc site.
 func (c *Client) GetSite(siteDomainName string) (*Site, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/sites/%s", c.HostBaseURL, siteDomainName), nil)
@@ -83,6 +125,32 @@ func (c *Client) UpdateSite(site Site) (*Site, error) {
 	return &resp, nil
 }
 
+// Update only given attributes of an existing site.
+func (c *Client) PatchSite(siteDomainName string, patch SitePatch) (*Site, error) {
+	rb, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/sites/%s", c.HostBaseURL, siteDomainName), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := Site{}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Delete existing site.
 func (c *Client) DeleteSite(siteDomainName string) error {
 	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/sites/%s", c.HostBaseURL, siteDomainName), nil)
@@ -6,6 +6,7 @@ package ogosecurity
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 )
@@ -31,6 +32,38 @@ func (c *Client) GetAllTlsOptions() ([]TlsOptions, error) {
 	return resp.TlsOptions, nil
 }
 
+// Returns a page of user's TLS Options, pages are numbered from 0.
+func (c *Client) ListTlsOptions(page int, size int) (*TlsOptionsResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tls-options?page=%d&size=%d", c.HostBaseURL, page, size), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := TlsOptionsResponse{}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Returns an iterator over user's TLS Options of all pages.
+func (c *Client) AllTlsOptions() iter.Seq2[*TlsOptions, error] {
+	return listAll(func(page int, size int) ([]TlsOptions, int, error) {
+		resp, err := c.ListTlsOptions(page, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.TlsOptions, resp.Count, nil
+	})
+}
+
 // Returns a specifc TLS Options.
 func (c *Client) GetTlsOptions(tlsOptionsUid string) (*TlsOptions, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/tls-options/%s", c.HostBaseURL, tlsOptionsUid), nil)
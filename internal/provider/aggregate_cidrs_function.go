@@ -0,0 +1,78 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &aggregateCidrsFunction{}
+)
+
+// NewAggregateCidrsFunction is a helper function to simplify the provider implementation.
+func NewAggregateCidrsFunction() function.Function {
+	return &aggregateCidrsFunction{}
+}
+
+// aggregateCidrsFunction is the function implementation.
+type aggregateCidrsFunction struct{}
+
+// Metadata returns the function name.
+func (f *aggregateCidrsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aggregate_cidrs"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *aggregateCidrsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Aggregate IP addresses and CIDR ranges",
+		MarkdownDescription: "Returns the smallest list of CIDR ranges covering the same IP addresses as the given " +
+			"list, merging duplicate, overlapping and adjacent ranges, e.g. `192.0.2.0/25` and `192.0.2.128/25` " +
+			"into `192.0.2.0/24`. Ranges are returned sorted by address, IPv4 ranges first, and single addresses " +
+			"without prefix length.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "list",
+				ElementType: types.StringType,
+				Description: "List of IPv4 or IPv6 addresses, CIDR ranges or address ranges (`192.0.2.10-192.0.2.20`).",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run aggregates the IP ranges.
+func (f *aggregateCidrsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list []types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &list))
+	if resp.Error != nil {
+		return
+	}
+
+	prefixes := []netip.Prefix{}
+	for i, value := range list {
+		if value.IsNull() {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid IP range %d: value is null", i))
+			return
+		}
+		entryPrefixes, err := parseIpListEntry(value.ValueString())
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid IP range %d: %s", i, err))
+			return
+		}
+		prefixes = append(prefixes, entryPrefixes...)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, formatIpRanges(aggregateIpRanges(prefixes))))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAggregateCidrsFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::aggregate_cidrs([
+    "2001:db8::/33",
+    "192.0.2.128/25",
+    "192.0.2.0/25",
+    "192.0.2.10",
+    "2001:db8:8000::/33",
+    "198.51.100.1",
+  ])
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("192.0.2.0/24"),
+						knownvalue.StringExact("198.51.100.1"),
+						knownvalue.StringExact("2001:db8::/32"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAggregateCidrsFunctionInvalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::aggregate_cidrs(["192.0.2.0/24", "192.0.2.0/33"])
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid IP range 1: invalid CIDR range "192.0.2.0/33"`),
+			},
+		},
+	})
+}
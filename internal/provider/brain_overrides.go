@@ -0,0 +1,195 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// brainOverrideEpsilon is the relative tolerance used to compare brain
+// parameter values. Ogo API stores brain parameters as single precision
+// floats, so values like 0.8 may be returned as 0.80000001.
+const brainOverrideEpsilon = 1e-6
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.Float64Typable                    = brainOverrideType{}
+	_ basetypes.Float64ValuableWithSemanticEquals = brainOverrideValue{}
+)
+
+// brainOverrideType is a Float64 type whose values are compared with a
+// relative tolerance.
+type brainOverrideType struct {
+	basetypes.Float64Type
+}
+
+func (t brainOverrideType) Equal(o attr.Type) bool {
+	other, ok := o.(brainOverrideType)
+	if !ok {
+		return false
+	}
+
+	return t.Float64Type.Equal(other.Float64Type)
+}
+
+func (t brainOverrideType) String() string {
+	return "brainOverrideType"
+}
+
+func (t brainOverrideType) ValueFromFloat64(_ context.Context, in basetypes.Float64Value) (basetypes.Float64Valuable, diag.Diagnostics) {
+	return brainOverrideValue{Float64Value: in}, nil
+}
+
+func (t brainOverrideType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.Float64Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	float64Value, ok := attrValue.(basetypes.Float64Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	float64Valuable, diags := t.ValueFromFloat64(ctx, float64Value)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting Float64Value to Float64Valuable: %v", diags)
+	}
+
+	return float64Valuable, nil
+}
+
+func (t brainOverrideType) ValueType(_ context.Context) attr.Value {
+	return brainOverrideValue{}
+}
+
+// brainOverrideValue is a brain parameter value.
+type brainOverrideValue struct {
+	basetypes.Float64Value
+}
+
+func (v brainOverrideValue) Equal(o attr.Value) bool {
+	other, ok := o.(brainOverrideValue)
+	if !ok {
+		return false
+	}
+
+	return v.Float64Value.Equal(other.Float64Value)
+}
+
+func (v brainOverrideValue) Type(_ context.Context) attr.Type {
+	return brainOverrideType{}
+}
+
+// Float64SemanticEquals returns true if both values are equal within
+// brainOverrideEpsilon relative tolerance.
+func (v brainOverrideValue) Float64SemanticEquals(_ context.Context, newValuable basetypes.Float64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(brainOverrideValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return brainOverrideEqual(v.ValueFloat64(), newValue.ValueFloat64()), diags
+}
+
+// brainOverrideEqual compares two brain parameter values.
+func brainOverrideEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+
+	return math.Abs(a-b) <= brainOverrideEpsilon*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// validateBrainOverrides checks brain_overrides keys and values against
+// brain parameters catalog.
+func validateBrainOverrides(overrides types.Map, catalog []ogosecurity.BrainParameter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if overrides.IsNull() || overrides.IsUnknown() {
+		return diags
+	}
+
+	params := make(map[string]ogosecurity.BrainParameter, len(catalog))
+	for _, param := range catalog {
+		params[param.Key] = param
+	}
+
+	keys := make([]string, 0, len(overrides.Elements()))
+	for key := range overrides.Elements() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		attrPath := path.Root("brain_overrides").AtMapKey(key)
+
+		param, ok := params[key]
+		if !ok {
+			diags.AddAttributeError(
+				attrPath,
+				"Unknown brain parameter",
+				fmt.Sprintf("Brain parameter %q doesn't exist. List of available brain parameters can be retrieved from `ogo_shield_brain_parameters` data source.", key),
+			)
+			continue
+		}
+
+		value, ok := overrides.Elements()[key].(brainOverrideValue)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		v := value.ValueFloat64()
+		if (param.MinValue != nil && v < *param.MinValue) || (param.MaxValue != nil && v > *param.MaxValue) {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid brain parameter value",
+				fmt.Sprintf("Brain parameter %q (%s) value must be %s, got: %s.", key, param.Name, brainParameterRange(param), strconv.FormatFloat(v, 'f', -1, 64)),
+			)
+		}
+	}
+
+	return diags
+}
+
+// brainParameterRange returns a human readable range of accepted values.
+func brainParameterRange(param ogosecurity.BrainParameter) string {
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	switch {
+	case param.MinValue != nil && param.MaxValue != nil:
+		return "between " + format(*param.MinValue) + " and " + format(*param.MaxValue)
+	case param.MinValue != nil:
+		return "at least " + format(*param.MinValue)
+	case param.MaxValue != nil:
+		return "at most " + format(*param.MaxValue)
+	default:
+		return "a number"
+	}
+}
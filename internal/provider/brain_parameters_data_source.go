@@ -0,0 +1,143 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &brainParametersDataSource{}
+	_ datasource.DataSourceWithConfigure = &brainParametersDataSource{}
+)
+
+// brainParametersDataSourceModel maps the data source schema data.
+type brainParametersDataSourceModel struct {
+	Parameters []brainParametersModel `tfsdk:"parameters"`
+}
+
+// brainParametersModel maps brain parameter schema data.
+type brainParametersModel struct {
+	Key          types.String  `tfsdk:"key"`
+	Name         types.String  `tfsdk:"name"`
+	Description  types.String  `tfsdk:"description"`
+	DefaultValue types.Float64 `tfsdk:"default_value"`
+	MinValue     types.Float64 `tfsdk:"min_value"`
+	MaxValue     types.Float64 `tfsdk:"max_value"`
+}
+
+func NewBrainParametersDataSource() datasource.DataSource {
+	return &brainParametersDataSource{}
+}
+
+type brainParametersDataSource struct {
+	client *ogosecurity.Client
+}
+
+func (d *brainParametersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_brain_parameters"
+}
+
+func (d *brainParametersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"parameters": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed:    true,
+							Description: "Key used to reference this brain parameter in `brain_overrides` attribute of `ogo_shield_site` resource.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Human readable name of the brain parameter.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the brain parameter.",
+						},
+						"default_value": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Value used when the brain parameter is not overridden.",
+						},
+						"min_value": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Minimum accepted value, if any.",
+						},
+						"max_value": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Maximum accepted value, if any.",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: "Get the list of brain parameters which can be overridden on a site.\n\n" +
+			"Use this data source to retrieve brain parameter keys, default values and accepted " +
+			"ranges to be used in `brain_overrides` attribute of `ogo_shield_site` resource.",
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *brainParametersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ogosecurity.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *brainParametersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state brainParametersDataSourceModel
+
+	parameters, err := d.client.GetAllBrainParameters()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Ogo Brain Parameters",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Parameters = []brainParametersModel{}
+	for _, p := range parameters {
+		state.Parameters = append(state.Parameters, brainParametersModel{
+			Key:          types.StringValue(p.Key),
+			Name:         types.StringValue(p.Name),
+			Description:  types.StringValue(p.Description),
+			DefaultValue: types.Float64Value(p.DefaultValue),
+			MinValue:     types.Float64PointerValue(p.MinValue),
+			MaxValue:     types.Float64PointerValue(p.MaxValue),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
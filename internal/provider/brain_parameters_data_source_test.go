@@ -0,0 +1,28 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBrainParametersDataSource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `data "ogo_shield_brain_parameters" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ogo_shield_brain_parameters.test", "parameters.0.key"),
+					resource.TestCheckResourceAttrSet("data.ogo_shield_brain_parameters.test", "parameters.0.name"),
+					resource.TestCheckResourceAttrSet("data.ogo_shield_brain_parameters.test", "parameters.0.default_value"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,290 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = pemCertificateType{}
+	_ basetypes.StringValuableWithSemanticEquals = pemCertificateValue{}
+	_ validator.String                           = caCertificateValidator{}
+)
+
+// pemCertificateType is a String type for PEM encoded certificates, whose
+// values are compared by their decoded certificates so that line endings and
+// indentation of PEM data are ignored.
+type pemCertificateType struct {
+	basetypes.StringType
+}
+
+func (t pemCertificateType) Equal(o attr.Type) bool {
+	other, ok := o.(pemCertificateType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t pemCertificateType) String() string {
+	return "pemCertificateType"
+}
+
+func (t pemCertificateType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return pemCertificateValue{StringValue: in}, nil
+}
+
+func (t pemCertificateType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t pemCertificateType) ValueType(_ context.Context) attr.Value {
+	return pemCertificateValue{}
+}
+
+// pemCertificateValue is a PEM encoded certificate.
+type pemCertificateValue struct {
+	basetypes.StringValue
+}
+
+// pemCertificateStringValue returns a pemCertificateValue of s.
+func pemCertificateStringValue(s string) pemCertificateValue {
+	return pemCertificateValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v pemCertificateValue) Equal(o attr.Value) bool {
+	other, ok := o.(pemCertificateValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v pemCertificateValue) Type(_ context.Context) attr.Type {
+	return pemCertificateType{}
+}
+
+// StringSemanticEquals returns true if both values encode the same
+// certificates.
+func (v pemCertificateValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(pemCertificateValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return pemCertificatesEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// parsePemCertificates parses PEM encoded certificates. Data other than
+// whitespace around PEM blocks is rejected, so that truncated certificates
+// are reported.
+func parsePemCertificates(s string) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	rest := []byte(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q, expected CERTIFICATE", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		if len(certs) == 0 {
+			return nil, errors.New("no PEM encoded certificate found, certificate must start with -----BEGIN CERTIFICATE----- and end with -----END CERTIFICATE-----")
+		}
+		return nil, fmt.Errorf("unexpected data after certificate %d, certificate may be truncated", len(certs))
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
+
+// pemCertificatesEqual returns true if a and b encode the same certificates,
+// falling back to comparison without whitespace if they can't be parsed.
+func pemCertificatesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	certsA, errA := parsePemCertificates(a)
+	certsB, errB := parsePemCertificates(b)
+	if errA != nil || errB != nil {
+		return strings.Join(strings.Fields(a), "") == strings.Join(strings.Fields(b), "")
+	}
+	if len(certsA) != len(certsB) {
+		return false
+	}
+	for i := range certsA {
+		if !certsA[i].Equal(certsB[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CaCertInfoModel maps certificate authority information.
+type CaCertInfoModel struct {
+	Subject           types.String `tfsdk:"subject"`
+	FingerprintSha256 types.String `tfsdk:"fingerprint_sha256"`
+	NotAfter          types.String `tfsdk:"not_after"`
+}
+
+// caCertsInfo returns information about certificate authorities of PEM
+// encoded certificates, certificates which can't be parsed being skipped.
+func caCertsInfo(pemCerts []pemCertificateValue) []CaCertInfoModel {
+	info := []CaCertInfoModel{}
+	for _, pemCert := range pemCerts {
+		certs, err := parsePemCertificates(pemCert.ValueString())
+		if err != nil {
+			continue
+		}
+		for _, cert := range certs {
+			fingerprint := sha256.Sum256(cert.Raw)
+			info = append(info, CaCertInfoModel{
+				Subject:           types.StringValue(cert.Subject.String()),
+				FingerprintSha256: types.StringValue(hex.EncodeToString(fingerprint[:])),
+				NotAfter:          types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339)),
+			})
+		}
+	}
+
+	return info
+}
+
+// caCertsExpiryDiagnostics warns about certificate authorities which are
+// expired or expire within days.
+func caCertsExpiryDiagnostics(pemCerts []pemCertificateValue, days int64, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, pemCert := range pemCerts {
+		certs, err := parsePemCertificates(pemCert.ValueString())
+		if err != nil {
+			continue
+		}
+		for _, cert := range certs {
+			certPath := path.Root("client_auth_ca_certs").AtSetValue(pemCert)
+			switch {
+			case !cert.NotAfter.After(now):
+				diags.AddAttributeWarning(
+					certPath,
+					"Expired CA certificate",
+					fmt.Sprintf("Certificate authority %q expired on %s, client certificates it issued are rejected.",
+						cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339)),
+				)
+			case cert.NotAfter.Before(now.AddDate(0, 0, int(days))):
+				diags.AddAttributeWarning(
+					certPath,
+					"CA certificate expires soon",
+					fmt.Sprintf("Certificate authority %q expires on %s, in less than %d days. Add its renewed certificate to client_auth_ca_certs.",
+						cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339), days),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// caCertificateValidator validates that a string holds PEM encoded
+// certificate authorities.
+type caCertificateValidator struct{}
+
+func (v caCertificateValidator) Description(_ context.Context) string {
+	return "value must be a PEM encoded CA certificate"
+}
+
+func (v caCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v caCertificateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	certs, err := parsePemCertificates(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CA certificate",
+			"Value must be a PEM encoded X.509 certificate, got: "+err.Error()+".",
+		)
+		return
+	}
+
+	for _, cert := range certs {
+		if !cert.BasicConstraintsValid || !cert.IsCA {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid CA certificate",
+				fmt.Sprintf("Certificate %q is not a certificate authority, use the certificate of the CA which issued client certificates.", cert.Subject.String()),
+			)
+		}
+	}
+}
+
+// caCertificate returns a validator which ensures that any configured string
+// value holds PEM encoded certificate authorities.
+func caCertificate() validator.String {
+	return caCertificateValidator{}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testPemCertificate returns a self-signed PEM encoded certificate.
+func testPemCertificate(t *testing.T, commonName string, isCA bool, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestCaCertificateValidator(t *testing.T) {
+	ca := testPemCertificate(t, "Foo CA", true, time.Now().AddDate(1, 0, 0))
+	leaf := testPemCertificate(t, "foo.example.com", false, time.Now().AddDate(1, 0, 0))
+
+	for name, tc := range map[string]struct {
+		value string
+		valid bool
+	}{
+		"CA certificate":         {value: ca, valid: true},
+		"CA certificates bundle": {value: ca + ca, valid: true},
+		"leaf certificate":       {value: leaf, valid: false},
+		"truncated certificate":  {value: ca[:len(ca)/2], valid: false},
+		"trailing data":          {value: ca + "MIIDnTCCAoWgAwIBAgIU\n", valid: false},
+		"private key":            {value: strings.ReplaceAll(ca, "CERTIFICATE", "PRIVATE KEY"), valid: false},
+		"not PEM":                {value: "foo", valid: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("client_auth_ca_certs"), ConfigValue: types.StringValue(tc.value)}
+			resp := &validator.StringResponse{}
+			caCertificate().ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() == tc.valid {
+				t.Errorf("expected validity to be %t, got %v", tc.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPemCertificateSemanticEquals(t *testing.T) {
+	ca := testPemCertificate(t, "Foo CA", true, time.Now().AddDate(1, 0, 0))
+	other := testPemCertificate(t, "Bar CA", true, time.Now().AddDate(1, 0, 0))
+
+	for name, tc := range map[string]struct {
+		value    string
+		expected bool
+	}{
+		"same certificate":       {value: ca, expected: true},
+		"without final newline":  {value: strings.TrimSuffix(ca, "\n"), expected: true},
+		"CRLF line endings":      {value: strings.ReplaceAll(ca, "\n", "\r\n"), expected: true},
+		"surrounding whitespace": {value: "\n  " + ca + "\n\n", expected: true},
+		"other certificate":      {value: other, expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := pemCertificateStringValue(ca).StringSemanticEquals(context.Background(), pemCertificateStringValue(tc.value))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if equal != tc.expected {
+				t.Errorf("expected semantic equality to be %t, got %t", tc.expected, equal)
+			}
+		})
+	}
+}
+
+func TestCaCertsInfo(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	info := caCertsInfo([]pemCertificateValue{
+		pemCertificateStringValue(testPemCertificate(t, "Foo CA", true, notAfter)),
+		pemCertificateStringValue("foo"),
+	})
+
+	if len(info) != 1 {
+		t.Fatalf("expected information about 1 certificate, got %v", info)
+	}
+	if info[0].Subject.ValueString() != "CN=Foo CA" || info[0].NotAfter.ValueString() != "2030-01-02T03:04:05Z" || len(info[0].FingerprintSha256.ValueString()) != 64 {
+		t.Errorf("unexpected certificate information %v", info[0])
+	}
+}
+
+func TestCaCertsExpiryDiagnostics(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	certs := []pemCertificateValue{
+		pemCertificateStringValue(testPemCertificate(t, "Expired CA", true, now.AddDate(0, 0, -1))),
+		pemCertificateStringValue(testPemCertificate(t, "Expiring CA", true, now.AddDate(0, 0, 10))),
+		pemCertificateStringValue(testPemCertificate(t, "Valid CA", true, now.AddDate(1, 0, 0))),
+	}
+
+	for days, warnings := range map[int64]int{0: 1, 30: 2, 400: 3} {
+		if diags := caCertsExpiryDiagnostics(certs, days, now); diags.WarningsCount() != warnings || diags.HasError() {
+			t.Errorf("expected %d warnings with %d days, got %v", warnings, days, diags)
+		}
+	}
+}
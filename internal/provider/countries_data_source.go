@@ -0,0 +1,170 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &countriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &countriesDataSource{}
+)
+
+// countriesDataSourceModel maps the data source schema data.
+type countriesDataSourceModel struct {
+	Regions   []types.String   `tfsdk:"regions"`
+	Countries []countriesModel `tfsdk:"countries"`
+	Codes     []types.String   `tfsdk:"codes"`
+}
+
+// countriesModel maps country schema data.
+type countriesModel struct {
+	Code types.String `tfsdk:"code"`
+	Name types.String `tfsdk:"name"`
+}
+
+func NewCountriesDataSource() datasource.DataSource {
+	return &countriesDataSource{}
+}
+
+type countriesDataSource struct {
+	client *ogosecurity.Client
+}
+
+func (d *countriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_countries"
+}
+
+func (d *countriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"regions": schema.SetAttribute{
+				Optional: true,
+				Description: "Only return countries included in these named regions. Supported values:\n" +
+					"  * **EU**: Member states of the European Union\n" +
+					"  * **EEA**: Member states of the European Economic Area\n" +
+					"  * **SANCTIONED**: Countries subject to broad EU or US trade sanctions.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf(countryRegionNames()...),
+					),
+				},
+			},
+			"countries": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Computed:    true,
+							Description: "ISO 3166-1 alpha-2 code of the country.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "English name of the country.",
+						},
+					},
+				},
+			},
+			"codes": schema.ListAttribute{
+				Computed:    true,
+				Description: "List of country codes, ready to be used in `blacklisted_countries` attribute of `ogo_shield_site` resource.",
+				ElementType: types.StringType,
+			},
+		},
+		MarkdownDescription: "Get the list of countries which can be blacklisted on a site.\n\n" +
+			"Use this data source to retrieve country codes to be used in `blacklisted_countries` " +
+			"attribute of `ogo_shield_site` resource, optionally restricted to named regions.",
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *countriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ogosecurity.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *countriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state countriesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	countries, err := d.client.GetAllCountries()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Ogo Countries",
+			err.Error(),
+		)
+		return
+	}
+
+	// Restrict countries to requested regions
+	var filter map[string]bool
+	if state.Regions != nil {
+		regions := []string{}
+		for _, region := range state.Regions {
+			regions = append(regions, region.ValueString())
+		}
+
+		filter = map[string]bool{}
+		for _, code := range expandCountryRegions(regions) {
+			filter[code] = true
+		}
+	}
+
+	// Map response body to model
+	state.Countries = []countriesModel{}
+	state.Codes = []types.String{}
+	for _, c := range countries {
+		code := strings.ToUpper(c.CountryCode)
+		if filter != nil && !filter[code] {
+			continue
+		}
+
+		state.Countries = append(state.Countries, countriesModel{
+			Code: types.StringValue(code),
+			Name: types.StringValue(c.CountryName),
+		})
+		state.Codes = append(state.Codes, types.StringValue(code))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCountriesDataSource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `data "ogo_shield_countries" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ogo_shield_countries.test", "countries.0.code"),
+					resource.TestCheckResourceAttrSet("data.ogo_shield_countries.test", "countries.0.name"),
+					resource.TestCheckTypeSetElemAttr("data.ogo_shield_countries.test", "codes.*", "FR"),
+					resource.TestCheckTypeSetElemAttr("data.ogo_shield_countries.test", "codes.*", "US"),
+				),
+			},
+			{
+				Config: providerConfig + `
+data "ogo_shield_countries" "test" {
+  regions = ["SANCTIONED"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ogo_shield_countries.test", "codes.#", "6"),
+					resource.TestCheckTypeSetElemAttr("data.ogo_shield_countries.test", "codes.*", "KP"),
+					resource.TestCheckTypeSetElemAttr("data.ogo_shield_countries.test", "codes.*", "IR"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Functions accept rules as dynamic values, so that both site attributes
+// and literal lists of objects, with only some attributes set, can be
+// given.
+
+// dynamicElements returns elements of a list, set or tuple value.
+func dynamicElements(value attr.Value) ([]attr.Value, error) {
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dynamicElements(v.UnderlyingValue())
+	case basetypes.ListValue:
+		return v.Elements(), nil
+	case basetypes.SetValue:
+		return v.Elements(), nil
+	case basetypes.TupleValue:
+		return v.Elements(), nil
+	default:
+		return nil, fmt.Errorf("expected a list, got %s", attrTypeName(value))
+	}
+}
+
+// dynamicAttributes returns attributes of an object or map value.
+func dynamicAttributes(value attr.Value) (map[string]attr.Value, error) {
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dynamicAttributes(v.UnderlyingValue())
+	case basetypes.ObjectValue:
+		return v.Attributes(), nil
+	case basetypes.MapValue:
+		return v.Elements(), nil
+	default:
+		return nil, fmt.Errorf("expected an object, got %s", attrTypeName(value))
+	}
+}
+
+// dynamicString returns value of a string attribute, or defaultValue if
+// attribute is not set.
+func dynamicString(attributes map[string]attr.Value, name string, defaultValue string) (string, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		return defaultValue, nil
+	}
+	if v, ok := value.(basetypes.DynamicValue); ok {
+		value = v.UnderlyingValue()
+	}
+
+	s, ok := value.(basetypes.StringValue)
+	if !ok {
+		return "", fmt.Errorf("expected %s to be a string, got %s", name, attrTypeName(value))
+	}
+
+	return s.ValueString(), nil
+}
+
+// dynamicBool returns value of a bool attribute, or defaultValue if
+// attribute is not set.
+func dynamicBool(attributes map[string]attr.Value, name string, defaultValue bool) (bool, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		return defaultValue, nil
+	}
+	if v, ok := value.(basetypes.DynamicValue); ok {
+		value = v.UnderlyingValue()
+	}
+
+	b, ok := value.(basetypes.BoolValue)
+	if !ok {
+		return false, fmt.Errorf("expected %s to be a bool, got %s", name, attrTypeName(value))
+	}
+
+	return b.ValueBool(), nil
+}
+
+// dynamicPriority returns value of an optional integer attribute, nil if
+// attribute is not set.
+func dynamicPriority(attributes map[string]attr.Value, name string) (*int64, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		return nil, nil
+	}
+	if v, ok := value.(basetypes.DynamicValue); ok {
+		value = v.UnderlyingValue()
+	}
+
+	switch v := value.(type) {
+	case basetypes.Int64Value:
+		return v.ValueInt64Pointer(), nil
+	case basetypes.NumberValue:
+		i, accuracy := v.ValueBigFloat().Int64()
+		if !v.ValueBigFloat().IsInt() || accuracy != big.Exact {
+			return nil, fmt.Errorf("expected %s to be an integer, got %s", name, v.ValueBigFloat().String())
+		}
+		return &i, nil
+	default:
+		return nil, fmt.Errorf("expected %s to be a number, got %s", name, attrTypeName(value))
+	}
+}
+
+// dynamicStrings returns values of a list of strings attribute, or an empty
+// list if attribute is not set.
+func dynamicStrings(attributes map[string]attr.Value, name string) ([]string, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		return []string{}, nil
+	}
+
+	elements, err := dynamicElements(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	values := []string{}
+	for _, element := range elements {
+		s, ok := stringElement(element)
+		if !ok || s.IsNull() {
+			return nil, fmt.Errorf("expected %s to be a list of strings, got %s element", name, attrTypeName(element))
+		}
+		values = append(values, s.ValueString())
+	}
+
+	return values, nil
+}
+
+// stringElement returns element as a string value, custom string types of
+// site attributes included.
+func stringElement(element attr.Value) (basetypes.StringValue, bool) {
+	switch v := element.(type) {
+	case basetypes.StringValue:
+		return v, true
+	case ipRangeValue:
+		return v.StringValue, true
+	default:
+		return basetypes.StringValue{}, false
+	}
+}
+
+// attrTypeName returns a human readable name of value type.
+func attrTypeName(value attr.Value) string {
+	if value == nil || value.IsNull() {
+		return "null"
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return attrTypeName(v.UnderlyingValue())
+	case basetypes.StringValue, ipRangeValue:
+		return "string"
+	case basetypes.BoolValue:
+		return "bool"
+	case basetypes.NumberValue, basetypes.Int64Value, basetypes.Float64Value:
+		return "number"
+	case basetypes.ListValue, basetypes.TupleValue:
+		return "list"
+	case basetypes.SetValue:
+		return "set"
+	case basetypes.ObjectValue, basetypes.MapValue:
+		return "object"
+	default:
+		return "unsupported type"
+	}
+}
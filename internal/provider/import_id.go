@@ -0,0 +1,65 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// parseOrganizationImportID returns the resource ID of an import ID with
+// format [<organization>/]<id>. Organization, if given, must match the
+// organization configured in the provider.
+func parseOrganizationImportID(client *ogosecurity.Client, importID string, idName string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	organization, id, found := strings.Cut(importID, "/")
+	if !found {
+		organization, id = "", importID
+	}
+
+	if id == "" || (found && organization == "") || strings.Contains(id, "/") {
+		diags.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID format <%s> or <organization>/<%s>, got: %q", idName, idName, importID),
+		)
+		return "", diags
+	}
+
+	if found {
+		diags.Append(checkImportOrganization(client, organization)...)
+	}
+
+	return id, diags
+}
+
+// checkImportOrganization returns an error if organization of an imported
+// resource doesn't match the organization configured in the provider.
+func checkImportOrganization(client *ogosecurity.Client, organization string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client != nil && organization != "" && organization != client.Organization {
+		diags.AddError(
+			"Invalid import organization",
+			fmt.Sprintf("Import organization %q doesn't match provider organization %q.", organization, client.Organization),
+		)
+	}
+
+	return diags
+}
+
+// setResourceIdentity sets resource identity if supported by Terraform.
+func setResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, value any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, value)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// parseIpList parses an IP list, with one IP address, CIDR range or address
+// range (first-last) per line. Blank lines and comments starting with # are
+// ignored, duplicate ranges are removed.
+func parseIpList(content string) ([]netip.Prefix, error) {
+	prefixes := []netip.Prefix{}
+	seen := map[netip.Prefix]bool{}
+	for i, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		entryPrefixes, err := parseIpListEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		for _, prefix := range entryPrefixes {
+			if !seen[prefix] {
+				seen[prefix] = true
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	return prefixes, nil
+}
+
+// parseIpListEntry parses an IP address, CIDR range or address range
+// (first-last) into CIDR ranges.
+func parseIpListEntry(entry string) ([]netip.Prefix, error) {
+	first, last, isRange := strings.Cut(entry, "-")
+	if !isRange {
+		prefix, err := parseIpRange(entry)
+		if err != nil {
+			return nil, err
+		}
+		return []netip.Prefix{prefix.Masked()}, nil
+	}
+
+	firstAddr, err := netip.ParseAddr(strings.TrimSpace(first))
+	if err != nil || firstAddr.Zone() != "" {
+		return nil, fmt.Errorf("invalid first IP address of range %q", entry)
+	}
+	lastAddr, err := netip.ParseAddr(strings.TrimSpace(last))
+	if err != nil || lastAddr.Zone() != "" {
+		return nil, fmt.Errorf("invalid last IP address of range %q", entry)
+	}
+	if firstAddr.BitLen() != lastAddr.BitLen() {
+		return nil, fmt.Errorf("IP addresses of range %q are not of the same family", entry)
+	}
+	if lastAddr.Less(firstAddr) {
+		return nil, fmt.Errorf("first IP address of range %q is after the last one", entry)
+	}
+
+	return rangePrefixes(firstAddr, lastAddr), nil
+}
+
+// aggregateIpRanges returns the smallest list of CIDR ranges covering the
+// same addresses as prefixes, merging overlapping and adjacent ranges. IPv4
+// ranges are returned first, in address order.
+func aggregateIpRanges(prefixes []netip.Prefix) []netip.Prefix {
+	type bounds struct {
+		first netip.Addr
+		last  netip.Addr
+	}
+
+	ranges := []bounds{}
+	for _, prefix := range prefixes {
+		prefix = prefix.Masked()
+		ranges = append(ranges, bounds{first: prefix.Addr(), last: prefixLastAddr(prefix)})
+	}
+	slices.SortFunc(ranges, func(a, b bounds) int {
+		return a.first.Compare(b.first)
+	})
+
+	merged := []bounds{}
+	for _, r := range ranges {
+		if n := len(merged); n > 0 {
+			previous := &merged[n-1]
+			if previous.first.BitLen() == r.first.BitLen() &&
+				(r.first.Compare(previous.last) <= 0 || previous.last.Next() == r.first) {
+				if r.last.Compare(previous.last) > 0 {
+					previous.last = r.last
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	result := []netip.Prefix{}
+	for _, r := range merged {
+		result = append(result, rangePrefixes(r.first, r.last)...)
+	}
+
+	return result
+}
+
+// rangePrefixes returns the smallest list of CIDR ranges covering addresses
+// from first to last.
+func rangePrefixes(first netip.Addr, last netip.Addr) []netip.Prefix {
+	prefixes := []netip.Prefix{}
+	for first.IsValid() && first.Compare(last) <= 0 {
+		// Widen the range while it starts at first and ends before last
+		bits := first.BitLen()
+		for bits > 0 {
+			wider := netip.PrefixFrom(first, bits-1).Masked()
+			if wider.Addr() != first || prefixLastAddr(wider).Compare(last) > 0 {
+				break
+			}
+			bits--
+		}
+
+		prefix := netip.PrefixFrom(first, bits)
+		prefixes = append(prefixes, prefix)
+		first = prefixLastAddr(prefix).Next()
+	}
+
+	return prefixes
+}
+
+// prefixLastAddr returns the last address of a CIDR range.
+func prefixLastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+
+	return addr
+}
+
+// formatIpRanges returns CIDR ranges as strings, single address ranges
+// being returned as addresses.
+func formatIpRanges(prefixes []netip.Prefix) []string {
+	values := []string{}
+	for _, prefix := range prefixes {
+		values = append(values, formatIpRange(prefix))
+	}
+
+	return values
+}
+
+// formatIpRange returns a CIDR range as a string, a single address range
+// being returned as an address.
+func formatIpRange(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+
+	return prefix.String()
+}
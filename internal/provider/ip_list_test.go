@@ -0,0 +1,91 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestParseIpList(t *testing.T) {
+	content := "# Partners\r\n192.0.2.10\r\n\r\n192.0.2.15/24 # host bits\n" +
+		"10.0.0.0-10.0.0.255\n10.0.1.0 - 10.0.1.2\n2001:db8::-2001:db8::ffff\n192.0.2.0/24\n"
+
+	prefixes, err := parseIpList(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"192.0.2.10", "192.0.2.0/24", "10.0.0.0/24", "10.0.1.0/31", "10.0.1.2", "2001:db8::/112"}
+	if actual := formatIpRanges(prefixes); !slices.Equal(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestParseIpListInvalid(t *testing.T) {
+	for content, expected := range map[string]string{
+		"192.0.2.10\n\n192.0.2.300":  `line 3: invalid IP address "192.0.2.300"`,
+		"10.0.0.0/8\n10.0.0.0/40":    `line 2: invalid CIDR range "10.0.0.0/40"`,
+		"10.0.0.9-10.0.0.1":          `line 1: first IP address of range "10.0.0.9-10.0.0.1" is after the last one`,
+		"10.0.0.1-2001:db8::1":       `line 1: IP addresses of range "10.0.0.1-2001:db8::1" are not of the same family`,
+		"10.0.0.1-":                  `line 1: invalid last IP address of range "10.0.0.1-"`,
+		"office # Paris\n10.0.0.0/8": `line 1: invalid IP address "office"`,
+	} {
+		if _, err := parseIpList(content); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q for %q, got %v", expected, content, err)
+		}
+	}
+}
+
+func TestAggregateIpRanges(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ranges   []string
+		expected []string
+	}{
+		"empty": {
+			ranges:   []string{},
+			expected: []string{},
+		},
+		"adjacent": {
+			ranges:   []string{"192.0.2.128/25", "192.0.2.0/25"},
+			expected: []string{"192.0.2.0/24"},
+		},
+		"overlapping": {
+			ranges:   []string{"10.0.0.0/8", "10.1.0.0/16", "10.255.255.255"},
+			expected: []string{"10.0.0.0/8"},
+		},
+		"adjacent but not aligned": {
+			ranges:   []string{"10.0.1.0/24", "10.0.2.0/24"},
+			expected: []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		"addresses": {
+			ranges:   []string{"10.0.0.3", "10.0.0.1", "10.0.0.2", "10.0.0.1"},
+			expected: []string{"10.0.0.1", "10.0.0.2/31"},
+		},
+		"families": {
+			ranges:   []string{"2001:db8::1", "2001:db8::", "192.0.2.1", "0.0.0.0/0"},
+			expected: []string{"0.0.0.0/0", "2001:db8::/127"},
+		},
+		"last addresses": {
+			ranges:   []string{"255.255.255.254", "255.255.255.255"},
+			expected: []string{"255.255.255.254/31"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			prefixes := []netip.Prefix{}
+			for _, r := range tc.ranges {
+				prefix, err := parseIpRange(r)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				prefixes = append(prefixes, prefix)
+			}
+
+			if actual := formatIpRanges(aggregateIpRanges(prefixes)); !slices.Equal(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
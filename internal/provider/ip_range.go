@@ -0,0 +1,192 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = ipRangeType{}
+	_ basetypes.StringValuableWithSemanticEquals = ipRangeValue{}
+	_ validator.String                           = ipRangeValidator{}
+)
+
+// ipRangeType is a String type for IP addresses or CIDR ranges, whose values
+// are compared as ranges so that IP address 10.0.0.1 and CIDR range
+// 10.0.0.1/32, or different notations of an IPv6 address, are equal.
+type ipRangeType struct {
+	basetypes.StringType
+}
+
+func (t ipRangeType) Equal(o attr.Type) bool {
+	other, ok := o.(ipRangeType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ipRangeType) String() string {
+	return "ipRangeType"
+}
+
+func (t ipRangeType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ipRangeValue{StringValue: in}, nil
+}
+
+func (t ipRangeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t ipRangeType) ValueType(_ context.Context) attr.Value {
+	return ipRangeValue{}
+}
+
+// ipRangeValue is an IP address or CIDR range.
+type ipRangeValue struct {
+	basetypes.StringValue
+}
+
+// ipRangeStringValue returns an ipRangeValue of s.
+func ipRangeStringValue(s string) ipRangeValue {
+	return ipRangeValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v ipRangeValue) Equal(o attr.Value) bool {
+	other, ok := o.(ipRangeValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ipRangeValue) Type(_ context.Context) attr.Type {
+	return ipRangeType{}
+}
+
+// StringSemanticEquals returns true if both values are the same IP address
+// or CIDR range.
+func (v ipRangeValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ipRangeValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return ipRangeEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// parseIpRange parses an IP address, as a single address range, or a CIDR
+// range.
+func parseIpRange(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR range %q", s)
+		}
+		return prefix, nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil || addr.Zone() != "" {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", s)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ipRangeEqual returns true if a and b are the same IP address or CIDR
+// range, falling back to string comparison if they can't be parsed.
+func ipRangeEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	prefixA, errA := parseIpRange(a)
+	prefixB, errB := parseIpRange(b)
+
+	return errA == nil && errB == nil && prefixA == prefixB
+}
+
+// canonicalIpRange returns the canonical form of an IP address or CIDR
+// range, single address ranges being returned as addresses. Values which
+// can't be parsed are returned as is.
+func canonicalIpRange(s string) string {
+	prefix, err := parseIpRange(s)
+	if err != nil {
+		return s
+	}
+
+	return formatIpRange(prefix)
+}
+
+// ipRangeValidator validates that a string is an IPv4 or IPv6 address or
+// CIDR range.
+type ipRangeValidator struct{}
+
+func (v ipRangeValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address or CIDR range"
+}
+
+func (v ipRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipRangeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseIpRange(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP address or CIDR range",
+			fmt.Sprintf("Value must be an IPv4 or IPv6 address (e.g. 192.0.2.10 or 2001:db8::10) "+
+				"or CIDR range (e.g. 192.0.2.0/24 or 2001:db8::/64), got: %s.", err),
+		)
+	}
+}
+
+// ipRange returns a validator which ensures that any configured string value
+// is an IPv4 or IPv6 address or CIDR range.
+func ipRange() validator.String {
+	return ipRangeValidator{}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIpRangeSemanticEquals(t *testing.T) {
+	for name, tc := range map[string]struct {
+		prior    string
+		new      string
+		expected bool
+	}{
+		"same address":            {"10.0.0.1", "10.0.0.1", true},
+		"address and /32 range":   {"10.0.0.1", "10.0.0.1/32", true},
+		"address and /128 range":  {"2001:db8::1", "2001:db8::1/128", true},
+		"IPv6 notations":          {"2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1", true},
+		"IPv6 range notations":    {"2001:DB8:0:0::/64", "2001:db8::/64", true},
+		"different addresses":     {"10.0.0.1", "10.0.0.2", false},
+		"address and wider range": {"10.0.0.1", "10.0.0.1/24", false},
+		"different ranges":        {"10.0.0.0/24", "10.0.0.0/16", false},
+		"invalid values":          {"office", "Office", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := ipRangeStringValue(tc.prior).StringSemanticEquals(context.Background(), ipRangeStringValue(tc.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != tc.expected {
+				t.Errorf("expected %q and %q semantic equality to be %t", tc.prior, tc.new, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalIpRange(t *testing.T) {
+	for value, expected := range map[string]string{
+		"10.0.0.1":             "10.0.0.1",
+		"10.0.0.1/32":          "10.0.0.1",
+		"10.0.0.0/24":          "10.0.0.0/24",
+		"2001:DB8:0:0:0:0:0:1": "2001:db8::1",
+		"2001:db8:0000::/64":   "2001:db8::/64",
+		"not an IP address":    "not an IP address",
+		"::ffff:10.0.0.1":      "::ffff:10.0.0.1",
+		"2001:db8::1/128":      "2001:db8::1",
+	} {
+		if actual := canonicalIpRange(value); actual != expected {
+			t.Errorf("expected canonical IP range of %q to be %q, got %q", value, expected, actual)
+		}
+	}
+}
+
+func TestIpRangeValidator(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"192.0.2.10":       true,
+		"192.0.2.0/24":     true,
+		"2001:db8::10":     true,
+		"2001:db8::/64":    true,
+		"192.0.2":          false,
+		"192.0.2.0/33":     false,
+		"192.0.2.0-24":     false,
+		"fe80::1%eth0":     false,
+		"2001:db8::/129":   false,
+		"192.0.2.10 ":      false,
+		"office.localhost": false,
+	} {
+		req := validator.StringRequest{Path: path.Root("ip"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		ipRange().ValidateString(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("expected %q validity to be %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
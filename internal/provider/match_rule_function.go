@@ -0,0 +1,231 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &matchRuleFunction{}
+)
+
+// matchRuleResultModel maps the function result data.
+type matchRuleResultModel struct {
+	Matched types.Bool   `tfsdk:"matched"`
+	Index   types.Int64  `tfsdk:"index"`
+	Path    types.String `tfsdk:"path"`
+	Action  types.String `tfsdk:"action"`
+	Allowed types.Bool   `tfsdk:"allowed"`
+}
+
+// siteRule is a rule evaluated by functions.
+type siteRule struct {
+	Active         bool
+	Action         string
+	Cache          bool
+	Priority       *int64
+	Paths          []string
+	WhitelistedIps []string
+}
+
+// NewMatchRuleFunction is a helper function to simplify the provider implementation.
+func NewMatchRuleFunction() function.Function {
+	return &matchRuleFunction{}
+}
+
+// matchRuleFunction is the function implementation.
+type matchRuleFunction struct{}
+
+// Metadata returns the function name.
+func (f *matchRuleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "match_rule"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *matchRuleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Find the site rule applied to a request",
+		MarkdownDescription: "Evaluates site `rules` like Ogo Shield does for a request path and client IP address. " +
+			"Rules are parsed in order of declaration, inactive rules are skipped, and the first rule with a path " +
+			"matching the request path is applied. Rule paths are RE2 regular expressions matched from the start of the request path, " +
+			"so that `/admin` matches `/admin/login` and `^/admin$` only matches `/admin`. Rules with a `priority` are parsed first, by increasing priority. Request is allowed if no rule matches, or if client IP address " +
+			"is in one of `whitelisted_ips` addresses or CIDR ranges of the matching rule.\n\n" +
+			"Returns an object with attributes:\n" +
+			"  * `matched`: **true** if a rule matches the request path.\n" +
+			"  * `index`: Index of the matching rule in `rules`, null if none.\n" +
+			"  * `path`: Path of the matching rule which matches the request path, null if none.\n" +
+			"  * `action`: Action of the matching rule (default: **brain**), null if none.\n" +
+			"  * `allowed`: **true** if the client is allowed to access the request path.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "rules",
+				Description: "List of rules, like `rules` attribute of `ogo_shield_site` resource. " +
+					"Only `paths` is required, `active` defaults to **true** and `whitelisted_ips` to an empty list.",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "Request URL path, e.g. `/admin/login`.",
+			},
+			function.StringParameter{
+				Name:        "client_ip",
+				Description: "Client IP address, e.g. `192.0.2.10`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"matched": types.BoolType,
+				"index":   types.Int64Type,
+				"path":    types.StringType,
+				"action":  types.StringType,
+				"allowed": types.BoolType,
+			},
+		},
+	}
+}
+
+// Run evaluates rules for the request.
+func (f *matchRuleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rulesValue types.Dynamic
+	var path string
+	var clientIp string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &rulesValue, &path, &clientIp))
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := siteRulesFromValue(rulesValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid rules: "+err.Error())
+		return
+	}
+
+	ip, err := netip.ParseAddr(clientIp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid client IP address %q: %s", clientIp, err))
+		return
+	}
+
+	result := matchRuleResultModel{
+		Matched: types.BoolValue(false),
+		Index:   types.Int64Null(),
+		Path:    types.StringNull(),
+		Action:  types.StringNull(),
+		Allowed: types.BoolValue(true),
+	}
+
+	index, rulePath := matchRule(rules, path)
+	if index >= 0 {
+		allowed, err := ipAllowed(rules[index].WhitelistedIps, ip)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid rules: rule %d: %s", index, err))
+			return
+		}
+
+		result.Matched = types.BoolValue(true)
+		result.Index = types.Int64Value(int64(index))
+		result.Path = types.StringValue(rulePath)
+		result.Action = types.StringValue(rules[index].Action)
+		result.Allowed = types.BoolValue(allowed)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// siteRulesFromValue returns rules of a list of rule objects.
+func siteRulesFromValue(value attr.Value) ([]siteRule, error) {
+	elements, err := dynamicElements(value)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []siteRule{}
+	for i, element := range elements {
+		attributes, err := dynamicAttributes(element)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		var rule siteRule
+		if rule.Active, err = dynamicBool(attributes, "active", true); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if rule.Action, err = dynamicString(attributes, "action", "brain"); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if rule.Cache, err = dynamicBool(attributes, "cache", false); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if rule.Priority, err = dynamicPriority(attributes, "priority"); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if rule.Paths, err = dynamicStrings(attributes, "paths"); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		for _, p := range rule.Paths {
+			if _, err := regexp.Compile(p); err != nil {
+				return nil, fmt.Errorf("rule %d: paths: %q is not a valid regular expression: %w", i, p, err)
+			}
+		}
+		if rule.WhitelistedIps, err = dynamicStrings(attributes, "whitelisted_ips"); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// matchRule returns index and path of the first active rule, in order of
+// priority, with a path matching request path, index is -1 if no rule
+// matches.
+func matchRule(rules []siteRule, path string) (int, string) {
+	for _, i := range priorityOrder(rules, siteRule.priority) {
+		rule := rules[i]
+		if !rule.Active {
+			continue
+		}
+		for _, rulePath := range rule.Paths {
+			if rulePathMatches(rulePath, path) {
+				return i, rulePath
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+// priority returns the priority of the rule, nil if not set.
+func (r siteRule) priority() *int64 {
+	return r.Priority
+}
+
+// ipAllowed returns true if ip is one of whitelisted IP addresses or is in
+// one of whitelisted CIDR ranges.
+func ipAllowed(whitelistedIps []string, ip netip.Addr) (bool, error) {
+	ip = ip.Unmap()
+	for _, whitelistedIp := range whitelistedIps {
+		prefix, err := parseIpRange(whitelistedIp)
+		if err != nil {
+			return false, fmt.Errorf("whitelisted_ips: %w", err)
+		}
+		if prefix.IsSingleIP() && prefix.Addr().Unmap() == ip {
+			return true, nil
+		}
+		if prefix.Masked().Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
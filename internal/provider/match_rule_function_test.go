@@ -0,0 +1,146 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const matchRuleFunctionConfig = `
+locals {
+  rules = [
+    {
+      active          = false
+      paths           = ["/admin"]
+      whitelisted_ips = []
+    },
+    {
+      paths           = ["/admin", "/wp-admin"]
+      whitelisted_ips = ["10.10.9.0/24", "fded:b552:6f7e:fc6f::/64", "192.0.2.10"]
+    },
+    {
+      paths  = ["/"]
+      action = "bypass"
+    },
+  ]
+}
+`
+
+func TestMatchRuleFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: matchRuleFunctionConfig + `
+output "office" {
+  value = provider::ogo::match_rule(local.rules, "/admin/login", "10.10.9.42")
+}
+
+output "office_ipv6" {
+  value = provider::ogo::match_rule(local.rules, "/wp-admin/", "fded:b552:6f7e:fc6f::1")
+}
+
+output "outside" {
+  value = provider::ogo::match_rule(local.rules, "/admin", "198.51.100.1")
+}
+
+output "public" {
+  value = provider::ogo::match_rule(local.rules, "/index.html", "198.51.100.1")
+}
+
+output "none" {
+  value = provider::ogo::match_rule([], "/admin", "198.51.100.1")
+}
+
+output "regexp" {
+  value = provider::ogo::match_rule([{ paths = ["^/api/v[0-9]+/admin$"] }, { paths = ["^/api/"] }], "/api/v2/admin", "198.51.100.1")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("office", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(true),
+						"index":   knownvalue.Int64Exact(1),
+						"path":    knownvalue.StringExact("/admin"),
+						"action":  knownvalue.StringExact("brain"),
+						"allowed": knownvalue.Bool(true),
+					})),
+					statecheck.ExpectKnownOutputValueAtPath("office_ipv6", tfjsonpath.New("allowed"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("outside", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(true),
+						"index":   knownvalue.Int64Exact(1),
+						"path":    knownvalue.StringExact("/admin"),
+						"action":  knownvalue.StringExact("brain"),
+						"allowed": knownvalue.Bool(false),
+					})),
+					statecheck.ExpectKnownOutputValue("public", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(true),
+						"index":   knownvalue.Int64Exact(2),
+						"path":    knownvalue.StringExact("/"),
+						"action":  knownvalue.StringExact("bypass"),
+						"allowed": knownvalue.Bool(false),
+					})),
+					statecheck.ExpectKnownOutputValue("none", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(false),
+						"index":   knownvalue.Null(),
+						"path":    knownvalue.Null(),
+						"action":  knownvalue.Null(),
+						"allowed": knownvalue.Bool(true),
+					})),
+					statecheck.ExpectKnownOutputValue("regexp", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(true),
+						"index":   knownvalue.Int64Exact(0),
+						"path":    knownvalue.StringExact("^/api/v[0-9]+/admin$"),
+						"action":  knownvalue.StringExact("brain"),
+						"allowed": knownvalue.Bool(false),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestMatchRuleFunctionInvalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: matchRuleFunctionConfig + `
+output "test" {
+  value = provider::ogo::match_rule(local.rules, "/admin", "10.10.9")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid client IP address "10.10.9"`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::match_rule([{ paths = "/admin" }], "/admin", "10.10.9.1")
+}
+`,
+				ExpectError: regexp.MustCompile(`expected paths to be a list of strings|paths: expected a list`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::match_rule([{ paths = ["/admin("] }], "/admin", "10.10.9.1")
+}
+`,
+				ExpectError: regexp.MustCompile(`not a valid regular expression`),
+			},
+		},
+	})
+}
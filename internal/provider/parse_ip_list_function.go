@@ -0,0 +1,69 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &parseIpListFunction{}
+)
+
+// NewParseIpListFunction is a helper function to simplify the provider implementation.
+func NewParseIpListFunction() function.Function {
+	return &parseIpListFunction{}
+}
+
+// parseIpListFunction is the function implementation.
+type parseIpListFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseIpListFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_ip_list"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseIpListFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a text list of IP addresses and CIDR ranges",
+		MarkdownDescription: "Parses a text list of IP addresses, e.g. read with `file()`, and returns the list of IP " +
+			"addresses and CIDR ranges, which can be used in `ip_exceptions` or `whitelisted_ips` of `ogo_shield_site`.\n\n" +
+			"Each line holds an IPv4 or IPv6 address (`192.0.2.10`), CIDR range (`192.0.2.0/24`) or address range " +
+			"(`192.0.2.10-192.0.2.20`), which is converted to the CIDR ranges covering it. Blank lines and comments " +
+			"starting with `#` are ignored. Values are returned in order of the list, in canonical form with host bits " +
+			"of CIDR ranges cleared, and duplicates are removed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "content",
+				Description: "Text list of IP addresses, CIDR ranges or address ranges, one per line.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run parses the IP list.
+func (f *parseIpListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &content))
+	if resp.Error != nil {
+		return
+	}
+
+	prefixes, err := parseIpList(content)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IP list: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, formatIpRanges(prefixes)))
+}
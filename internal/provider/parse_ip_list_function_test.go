@@ -0,0 +1,69 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseIpListFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::parse_ip_list(<<-EOT
+    # Partner offices
+    192.0.2.10
+    192.0.2.0/24     # Paris
+
+    198.51.100.8-198.51.100.17
+    2001:DB8::1/128
+    192.0.2.10
+  EOT
+  )
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("192.0.2.10"),
+						knownvalue.StringExact("192.0.2.0/24"),
+						knownvalue.StringExact("198.51.100.8/29"),
+						knownvalue.StringExact("198.51.100.16/31"),
+						knownvalue.StringExact("2001:db8::1"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestParseIpListFunctionInvalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::parse_ip_list("192.0.2.10\n192.0.2\n")
+}
+`,
+				ExpectError: regexp.MustCompile(`line 2: invalid IP address "192.0.2"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = pathRegexpValidator{}
+
+// pathRegexpValidator validates that a string is a URL path regular
+// expression, compiled with the RE2 syntax Ogo Shield uses to match request
+// paths.
+type pathRegexpValidator struct{}
+
+func (v pathRegexpValidator) Description(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v pathRegexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pathRegexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid path regular expression",
+			fmt.Sprintf("%q is not a valid regular expression: %s. "+
+				"Paths use RE2 syntax, lookarounds and backreferences are not supported.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// pathRegexp returns a validator which ensures that any configured string
+// value is a valid URL path regular expression.
+func pathRegexp() validator.String {
+	return pathRegexpValidator{}
+}
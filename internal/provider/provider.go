@@ -6,10 +6,14 @@ package provider
 import (
 	"context"
 	"os"
+	"strconv"
 
 	ogosecurity "terraform-provider-ogo/internal/ogo"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,7 +24,9 @@ import (
 
 // Ensure ogoProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider = &ogoProvider{}
+	_ provider.Provider                  = &ogoProvider{}
+	_ provider.ProviderWithListResources = &ogoProvider{}
+	_ provider.ProviderWithFunctions     = &ogoProvider{}
 )
 
 func New(version string) func() provider.Provider {
@@ -41,10 +47,29 @@ type ogoProvider struct {
 
 // ogoProviderModel describes the provider data model.
 type ogoProviderModel struct {
-	Endpoint     types.String `tfsdk:"endpoint"`
-	Email        types.String `tfsdk:"email"`
-	ApiKey       types.String `tfsdk:"apikey"`
-	Organization types.String `tfsdk:"organization"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	Email            types.String `tfsdk:"email"`
+	ApiKey           types.String `tfsdk:"apikey"`
+	Organization     types.String `tfsdk:"organization"`
+	StrictValidation types.Bool   `tfsdk:"strict_validation"`
+}
+
+// ogoProviderData is the data passed to resources by the provider.
+type ogoProviderData struct {
+	Client *ogosecurity.Client
+	// StrictValidation turns plan-time warnings about likely configuration
+	// mistakes into errors.
+	StrictValidation bool
+}
+
+// validationDiagnostic returns a finding of plan-time analysis, as an error
+// if strict validation is enabled, as a warning otherwise.
+func validationDiagnostic(strict bool, p path.Path, summary string, detail string) diag.Diagnostic {
+	if strict {
+		return diag.NewAttributeErrorDiagnostic(p, summary, detail)
+	}
+
+	return diag.NewAttributeWarningDiagnostic(p, summary, detail)
 }
 
 func (p *ogoProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -72,6 +97,12 @@ func (p *ogoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				MarkdownDescription: "Organization code used to authenticate to Ogo Dashboard",
 				Required:            true,
 			},
+			"strict_validation": schema.BoolAttribute{
+				MarkdownDescription: "Report findings of plan-time analysis of resources, like unreachable site rules, " +
+					"looping rewrite rules or deprecated TLS versions, as errors instead of warnings (default: **false**). " +
+					"Can also be set with the `OGO_STRICT_VALIDATION` environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -92,6 +123,17 @@ func (p *ogoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	email := os.Getenv("OGO_EMAIL")
 	apikey := os.Getenv("OGO_APIKEY")
 	organization := os.Getenv("OGO_ORGANIZATION")
+	strictValidation := false
+	if value := os.Getenv("OGO_STRICT_VALIDATION"); value != "" {
+		var err error
+		if strictValidation, err = strconv.ParseBool(value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("strict_validation"),
+				"Invalid strict validation setting",
+				"The OGO_STRICT_VALIDATION environment variable must be a boolean, got: "+value,
+			)
+		}
+	}
 
 	// Configuration values are now available.
 	if !config.Endpoint.IsNull() {
@@ -112,6 +154,10 @@ func (p *ogoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		organization = config.Organization.ValueString()
 	}
 
+	if !config.StrictValidation.IsNull() {
+		strictValidation = config.StrictValidation.ValueBool()
+	}
+
 	// If any of the expected configurations are missing, return error
 	if endpoint == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -178,7 +224,11 @@ func (p *ogoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	}
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &ogoProviderData{
+		Client:           client,
+		StrictValidation: strictValidation,
+	}
+	resp.ListResourceData = client
 
 	tflog.Info(ctx, "Configured Ogo client", map[string]any{"success": true})
 }
@@ -186,8 +236,10 @@ func (p *ogoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 // DataSources defines the data sources implemented in the provider.
 func (p *ogoProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewBrainParametersDataSource,
 		NewClustersDataSource,
 		NewContractsDataSource,
+		NewCountriesDataSource,
 		NewOrganizationsDataSource,
 		NewTlsOptionsDataSource,
 	}
@@ -196,6 +248,28 @@ func (p *ogoProvider) DataSources(ctx context.Context) []func() datasource.DataS
 func (p *ogoProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSiteResource,
+		NewSiteIpExceptionResource,
+		NewSiteUrlExceptionResource,
+		NewSiteRuleResource,
+		NewSiteRewriteRuleResource,
 		NewTlsOptionsResource,
 	}
 }
+
+// ListResources defines the list resources implemented in the provider.
+func (p *ogoProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewSiteListResource,
+		NewTlsOptionsListResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *ogoProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewMatchRuleFunction,
+		NewRewriteFunction,
+		NewParseIpListFunction,
+		NewAggregateCidrsFunction,
+	}
+}
@@ -4,11 +4,15 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -34,3 +38,113 @@ func testAccProviderConfig() string {
 
 	return fmt.Sprintf(providerConfig, endpoint, email, organization, apikey)
 }
+
+// testUpgradeResourceState upgrades resource state fixture of version from
+// testdata to the current schema version, and checks it matches the current
+// version fixture without any difference. The current version fixture must
+// set all attributes of the current schema, so that it is kept in step with
+// the schema.
+func testUpgradeResourceState(t *testing.T, typeName string, version int64, currentVersion int64) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := testAccProtoV6ProviderFactories["ogo"]()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %s", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error getting provider schema: %s", err)
+	}
+	resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("resource %s not found in provider schema", typeName)
+	}
+	if resourceSchema.Version != currentVersion {
+		t.Fatalf("expected resource %s schema version %d, got %d", typeName, currentVersion, resourceSchema.Version)
+	}
+
+	prior, err := os.ReadFile(fmt.Sprintf("testdata/%s_v%d.json", typeName, version))
+	if err != nil {
+		t.Fatalf("unexpected error reading state fixture: %s", err)
+	}
+	expected, err := os.ReadFile(fmt.Sprintf("testdata/%s_v%d.json", typeName, currentVersion))
+	if err != nil {
+		t.Fatalf("unexpected error reading state fixture: %s", err)
+	}
+
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: prior},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error upgrading state: %s", err)
+	}
+	for _, d := range upgradeResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error upgrading state: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	valueType := resourceSchema.ValueType()
+	var fixture any
+	if err := json.Unmarshal(expected, &fixture); err != nil {
+		t.Fatalf("unexpected error decoding state fixture: %s", err)
+	}
+	for _, missing := range testMissingAttributes(valueType, fixture, "") {
+		t.Errorf("state fixture of version %d doesn't set attribute %s", currentVersion, missing)
+	}
+
+	upgraded, err := upgradeResp.UpgradedState.Unmarshal(valueType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding upgraded state: %s", err)
+	}
+	want, err := (&tfprotov6.RawState{JSON: expected}).UnmarshalWithOpts(valueType, tfprotov6.UnmarshalOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding state fixture: %s", err)
+	}
+
+	diffs, err := want.Diff(upgraded)
+	if err != nil {
+		t.Fatalf("unexpected error comparing states: %s", err)
+	}
+	for _, diff := range diffs {
+		t.Errorf("unexpected difference at %s: expected %s, got %s", diff.Path, diff.Value1, diff.Value2)
+	}
+}
+
+// testMissingAttributes returns paths of object attributes of typ which are
+// not set in value decoded from JSON, null values being set.
+func testMissingAttributes(typ tftypes.Type, value any, path string) []string {
+	missing := []string{}
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return missing
+		}
+		for name, attrType := range typ.AttributeTypes {
+			attrValue, ok := object[name]
+			if !ok {
+				missing = append(missing, path+name)
+				continue
+			}
+			missing = append(missing, testMissingAttributes(attrType, attrValue, path+name+".")...)
+		}
+	case tftypes.List:
+		elements, _ := value.([]any)
+		for _, element := range elements {
+			missing = append(missing, testMissingAttributes(typ.ElementType, element, path)...)
+		}
+	case tftypes.Set:
+		elements, _ := value.([]any)
+		for _, element := range elements {
+			missing = append(missing, testMissingAttributes(typ.ElementType, element, path)...)
+		}
+	}
+
+	return missing
+}
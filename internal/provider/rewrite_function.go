@@ -0,0 +1,150 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &rewriteFunction{}
+)
+
+// siteRewriteRule is a rewrite rule evaluated by functions.
+type siteRewriteRule struct {
+	Active             bool
+	Priority           *int64
+	RewriteSource      string
+	RewriteDestination string
+}
+
+// NewRewriteFunction is a helper function to simplify the provider implementation.
+func NewRewriteFunction() function.Function {
+	return &rewriteFunction{}
+}
+
+// rewriteFunction is the function implementation.
+type rewriteFunction struct{}
+
+// Metadata returns the function name.
+func (f *rewriteFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rewrite"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *rewriteFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Rewrite a request path with site rewrite rules",
+		MarkdownDescription: "Applies site `rewrite_rules` like Ogo Shield does to a request path, and returns the rewritten path. " +
+			"Rewrite rules are applied in order of declaration, rules with a `priority` first by increasing priority, and inactive rules are skipped. If `rewrite_source` regular " +
+			"expression matches the path, matches are replaced by `rewrite_destination`, where `$1` is replaced by the " +
+			"first submatch, and next rules are applied to the rewritten path.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "rewrite_rules",
+				Description: "List of rewrite rules, like `rewrite_rules` attribute of `ogo_shield_site` resource. " +
+					"Only `rewrite_source` and `rewrite_destination` are required, `active` defaults to **true**.",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "Request URL path, e.g. `/api/v1/users`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run rewrites the request path.
+func (f *rewriteFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rulesValue types.Dynamic
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &rulesValue, &path))
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := siteRewriteRulesFromValue(rulesValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid rewrite rules: "+err.Error())
+		return
+	}
+
+	rewritten, err := rewritePath(rules, path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid rewrite rules: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rewritten))
+}
+
+// siteRewriteRulesFromValue returns rewrite rules of a list of rewrite rule
+// objects.
+func siteRewriteRulesFromValue(value attr.Value) ([]siteRewriteRule, error) {
+	elements, err := dynamicElements(value)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []siteRewriteRule{}
+	for i, element := range elements {
+		attributes, err := dynamicAttributes(element)
+		if err != nil {
+			return nil, fmt.Errorf("rewrite rule %d: %w", i, err)
+		}
+
+		var rule siteRewriteRule
+		if rule.Active, err = dynamicBool(attributes, "active", true); err != nil {
+			return nil, fmt.Errorf("rewrite rule %d: %w", i, err)
+		}
+		if rule.Priority, err = dynamicPriority(attributes, "priority"); err != nil {
+			return nil, fmt.Errorf("rewrite rule %d: %w", i, err)
+		}
+		if rule.RewriteSource, err = dynamicString(attributes, "rewrite_source", ""); err != nil {
+			return nil, fmt.Errorf("rewrite rule %d: %w", i, err)
+		}
+		if rule.RewriteDestination, err = dynamicString(attributes, "rewrite_destination", ""); err != nil {
+			return nil, fmt.Errorf("rewrite rule %d: %w", i, err)
+		}
+		if rule.RewriteSource == "" {
+			return nil, fmt.Errorf("rewrite rule %d: rewrite_source is required", i)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// rewritePath applies active rewrite rules in order of priority to path.
+func rewritePath(rules []siteRewriteRule, path string) (string, error) {
+	for _, i := range priorityOrder(rules, siteRewriteRule.priority) {
+		rule := rules[i]
+		if !rule.Active {
+			continue
+		}
+
+		source, err := regexp.Compile(rule.RewriteSource)
+		if err != nil {
+			return "", fmt.Errorf("rewrite rule %d: invalid rewrite_source regular expression: %w", i, err)
+		}
+		if source.MatchString(path) {
+			path = source.ReplaceAllString(path, rule.RewriteDestination)
+		}
+	}
+
+	return path, nil
+}
+
+// priority returns the priority of the rewrite rule, nil if not set.
+func (r siteRewriteRule) priority() *int64 {
+	return r.Priority
+}
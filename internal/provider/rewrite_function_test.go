@@ -0,0 +1,82 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestRewriteFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  rewrite_rules = [
+    {
+      rewrite_source      = "^/api/v1/(.*)"
+      rewrite_destination = "/api/v2/$1"
+    },
+    {
+      active              = false
+      rewrite_source      = "^/api/v2/"
+      rewrite_destination = "/disabled/"
+    },
+    {
+      rewrite_source      = "^/api/v2/users"
+      rewrite_destination = "/users"
+    },
+  ]
+}
+
+output "chained" {
+  value = provider::ogo::rewrite(local.rewrite_rules, "/api/v1/users/42")
+}
+
+output "single" {
+  value = provider::ogo::rewrite(local.rewrite_rules, "/api/v1/orders")
+}
+
+output "unchanged" {
+  value = provider::ogo::rewrite(local.rewrite_rules, "/index.html")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("chained", knownvalue.StringExact("/users/42")),
+					statecheck.ExpectKnownOutputValue("single", knownvalue.StringExact("/api/v2/orders")),
+					statecheck.ExpectKnownOutputValue("unchanged", knownvalue.StringExact("/index.html")),
+				},
+			},
+		},
+	})
+}
+
+func TestRewriteFunctionInvalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::ogo::rewrite([{ rewrite_source = "^/api/(", rewrite_destination = "/" }], "/api/")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid rewrite_source regular expression`),
+			},
+		},
+	})
+}
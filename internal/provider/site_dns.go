@@ -0,0 +1,107 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// siteDnsRecordTtlHint is the recommended TTL in seconds of site DNS records.
+const siteDnsRecordTtlHint = 300
+
+// siteDnsRecordAttrTypes maps DNS record attribute types.
+var siteDnsRecordAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"type":     types.StringType,
+	"value":    types.StringType,
+	"ttl_hint": types.Int64Type,
+}
+
+// SiteDnsRecordModel maps DNS record schema data.
+type SiteDnsRecordModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Value   types.String `tfsdk:"value"`
+	TtlHint types.Int64  `tfsdk:"ttl_hint"`
+}
+
+// siteDnsRecords returns the DNS records to be configured for site domain
+// name to redirect to Ogo Shield cluster, a CNAME to the CDN entrypoint if
+// CDN is enabled, A and AAAA records to cluster entrypoints otherwise.
+func siteDnsRecords(site *ogosecurity.Site) []SiteDnsRecordModel {
+	records := []SiteDnsRecordModel{}
+
+	addRecord := func(recordType string, value string) {
+		if value == "" {
+			return
+		}
+
+		records = append(records, SiteDnsRecordModel{
+			Name:    types.StringValue(site.DomainName),
+			Type:    types.StringValue(recordType),
+			Value:   types.StringValue(value),
+			TtlHint: types.Int64Value(siteDnsRecordTtlHint),
+		})
+	}
+
+	if site.Cdn != nil && *site.Cdn != "" && site.Cluster.EntrypointCdn != "" {
+		addRecord("CNAME", site.Cluster.EntrypointCdn)
+		return records
+	}
+
+	addRecord("A", site.Cluster.Entrypoint4)
+	addRecord("AAAA", site.Cluster.Entrypoint6)
+
+	return records
+}
+
+// siteDnsRecordsValue returns the DNS records of site as a list value.
+func siteDnsRecordsValue(ctx context.Context, site *ogosecurity.Site) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: siteDnsRecordAttrTypes}, siteDnsRecords(site))
+}
+
+// siteDnsWarning returns a warning with the DNS records to be configured if
+// site DNS doesn't redirect to Ogo Shield cluster.
+func siteDnsWarning(site *ogosecurity.Site) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var reason string
+	switch site.Status {
+	case "CREATED":
+		reason = "is waiting for DNS to be configured"
+	case "DNS_ERROR":
+		reason = "has a partial DNS configuration"
+	case "OFFLINE":
+		reason = "DNS no longer redirects to Ogo Shield cluster"
+	default:
+		return diags
+	}
+
+	diags.AddWarning(
+		"Site DNS not configured",
+		fmt.Sprintf("Site %s status is %s, site %s. Configure the following DNS records "+
+			"(also available in dns_records attribute):\n%s", site.DomainName, site.Status, reason, formatDnsRecords(siteDnsRecords(site))),
+	)
+
+	return diags
+}
+
+// formatDnsRecords returns DNS records in zone file format.
+func formatDnsRecords(records []SiteDnsRecordModel) string {
+	lines := []string{}
+	for _, record := range records {
+		lines = append(lines, fmt.Sprintf("  %s %d IN %s %s",
+			record.Name.ValueString(), record.TtlHint.ValueInt64(), record.Type.ValueString(), record.Value.ValueString()))
+	}
+
+	return strings.Join(lines, "\n")
+}
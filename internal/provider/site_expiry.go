@@ -0,0 +1,168 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Exceptions and rules can expire. Ogo doesn't know about expiry dates, they
+// are kept in state: expired entries are not sent to Ogo anymore, and
+// expired entries still present on Ogo are read without expiry date, so that
+// the plan removes them.
+
+// expiresAtDescription is the description of expires_at attributes.
+func expiresAtDescription(label string) string {
+	return "Expiration date of this " + label + ", in RFC 3339 format (e.g. `2025-12-31T23:59:59Z`). " +
+		"Expired " + label + "s are removed from the site on next apply, and a warning is shown while they are still present on the site."
+}
+
+// isExpired returns true if expiresAt is set and not after now.
+func isExpired(expiresAt types.String, now time.Time) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+
+	return err == nil && !t.After(now)
+}
+
+// restoreExpiry restores expiry dates of entries read from Ogo from prior
+// entries with the same key, and returns entries still present on Ogo
+// although expired. Those are read without expiry date, so that the plan
+// removes them. Expired prior entries removed from Ogo are kept at their
+// prior position, so that they match configuration until removed from it.
+func restoreExpiry[T any](entries []T, prior []T, key func(T) string, expiresAt func(T) types.String, setExpiresAt func(*T, types.String), now time.Time) ([]T, []T) {
+	priorIndexes := map[string][]int{}
+	for i, e := range prior {
+		priorIndexes[key(e)] = append(priorIndexes[key(e)], i)
+	}
+
+	matched := make([]bool, len(prior))
+	expired := []T{}
+	for i := range entries {
+		indexes := priorIndexes[key(entries[i])]
+		if len(indexes) == 0 {
+			continue
+		}
+		p := indexes[0]
+		priorIndexes[key(entries[i])] = indexes[1:]
+		matched[p] = true
+
+		if isExpired(expiresAt(prior[p]), now) {
+			expired = append(expired, entries[i])
+			continue
+		}
+		setExpiresAt(&entries[i], expiresAt(prior[p]))
+	}
+
+	for p, e := range prior {
+		if !matched[p] && isExpired(expiresAt(e), now) {
+			entries = slices.Insert(entries, min(p, len(entries)), e)
+		}
+	}
+
+	return entries, expired
+}
+
+// withoutExpired returns entries which are not expired, to be sent to Ogo.
+func withoutExpired[T any](entries []T, expiresAt func(T) types.String, now time.Time) []T {
+	active := []T{}
+	for _, e := range entries {
+		if !isExpired(expiresAt(e), now) {
+			active = append(active, e)
+		}
+	}
+
+	return active
+}
+
+// expiredEntriesDiagnostics warns about expired entries still present on site.
+func expiredEntriesDiagnostics[T any](domainName string, attribute string, expired []T, key func(T) string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range expired {
+		diags.AddAttributeWarning(
+			path.Root(attribute),
+			"Expired site entry",
+			fmt.Sprintf("Entry %q of %s of site %s is expired but still present on the site, it will be removed on next apply.", key(e), attribute, domainName),
+		)
+	}
+
+	return diags
+}
+
+func ipExceptionModelKey(m IpExceptionModel) string {
+	return canonicalIpRange(m.Ip.ValueString())
+}
+
+func ipExceptionModelExpiresAt(m IpExceptionModel) types.String {
+	return m.ExpiresAt
+}
+
+func setIpExceptionModelExpiresAt(m *IpExceptionModel, expiresAt types.String) {
+	m.ExpiresAt = expiresAt
+}
+
+func urlExceptionModelKey(m UrlExceptionModel) string {
+	return m.Path.ValueString()
+}
+
+func urlExceptionModelExpiresAt(m UrlExceptionModel) types.String {
+	return m.ExpiresAt
+}
+
+func setUrlExceptionModelExpiresAt(m *UrlExceptionModel, expiresAt types.String) {
+	m.ExpiresAt = expiresAt
+}
+
+func ruleModelExpiresAt(m RuleModel) types.String {
+	return m.ExpiresAt
+}
+
+func setRuleModelExpiresAt(m *RuleModel, expiresAt types.String) {
+	m.ExpiresAt = expiresAt
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = rfc3339Validator{}
+
+// rfc3339Validator validates that a string is an RFC 3339 date and time.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 date and time"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid date",
+			fmt.Sprintf("%q is not an RFC 3339 date and time (e.g. 2025-12-31T23:59:59Z or 2025-12-31T23:59:59+01:00).", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// rfc3339 returns a validator which ensures that any configured string value
+// is an RFC 3339 date and time, e.g. the output of timestamp() or timeadd().
+func rfc3339() validator.String {
+	return rfc3339Validator{}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testExpiryNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testUrlExceptionModel(p string, expiresAt string) UrlExceptionModel {
+	m := UrlExceptionModel{
+		Path:      types.StringValue(p),
+		Comment:   types.StringNull(),
+		ExpiresAt: types.StringNull(),
+	}
+	if expiresAt != "" {
+		m.ExpiresAt = types.StringValue(expiresAt)
+	}
+
+	return m
+}
+
+func testUrlExceptionPaths(entries []UrlExceptionModel) []string {
+	paths := []string{}
+	for _, e := range entries {
+		paths = append(paths, e.Path.ValueString()+"@"+e.ExpiresAt.ValueString())
+	}
+
+	return paths
+}
+
+func TestIsExpired(t *testing.T) {
+	for value, expired := range map[string]bool{
+		"2025-06-01T11:59:59Z":      true,
+		"2025-06-01T12:00:00Z":      true,
+		"2025-06-01T13:30:00+02:00": true,
+		"2025-06-01T12:00:01Z":      false,
+		"2025-06-01T13:30:00Z":      false,
+	} {
+		if actual := isExpired(types.StringValue(value), testExpiryNow); actual != expired {
+			t.Errorf("expected %q expiry to be %t, got %t", value, expired, actual)
+		}
+	}
+
+	if isExpired(types.StringNull(), testExpiryNow) || isExpired(types.StringUnknown(), testExpiryNow) {
+		t.Errorf("expected null and unknown values not to be expired")
+	}
+}
+
+func TestWithoutExpired(t *testing.T) {
+	entries := []UrlExceptionModel{
+		testUrlExceptionModel("^/a", ""),
+		testUrlExceptionModel("^/b", "2025-05-31T00:00:00Z"),
+		testUrlExceptionModel("^/c", "2025-12-31T00:00:00Z"),
+	}
+
+	expected := []string{"^/a@", "^/c@2025-12-31T00:00:00Z"}
+	if actual := testUrlExceptionPaths(withoutExpired(entries, urlExceptionModelExpiresAt, testExpiryNow)); !slices.Equal(actual, expected) {
+		t.Errorf("expected entries %v, got %v", expected, actual)
+	}
+}
+
+func TestRestoreExpiry(t *testing.T) {
+	prior := []UrlExceptionModel{
+		testUrlExceptionModel("^/a", ""),
+		testUrlExceptionModel("^/b", "2025-05-31T00:00:00Z"),
+		testUrlExceptionModel("^/c", "2025-12-31T00:00:00Z"),
+	}
+
+	for name, tc := range map[string]struct {
+		read     []string
+		expected []string
+		expired  []string
+	}{
+		"expired entry removed": {
+			read:     []string{"^/a", "^/c"},
+			expected: []string{"^/a@", "^/b@2025-05-31T00:00:00Z", "^/c@2025-12-31T00:00:00Z"},
+			expired:  []string{},
+		},
+		"expired entry still present": {
+			read:     []string{"^/a", "^/b", "^/c"},
+			expected: []string{"^/a@", "^/b@", "^/c@2025-12-31T00:00:00Z"},
+			expired:  []string{"^/b@"},
+		},
+		"added outside of Terraform": {
+			read:     []string{"^/x", "^/c"},
+			expected: []string{"^/x@", "^/b@2025-05-31T00:00:00Z", "^/c@2025-12-31T00:00:00Z"},
+			expired:  []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			read := []UrlExceptionModel{}
+			for _, p := range tc.read {
+				read = append(read, testUrlExceptionModel(p, ""))
+			}
+
+			restored, expired := restoreExpiry(read, prior, urlExceptionModelKey, urlExceptionModelExpiresAt, setUrlExceptionModelExpiresAt, testExpiryNow)
+			if actual := testUrlExceptionPaths(restored); !slices.Equal(actual, tc.expected) {
+				t.Errorf("expected entries %v, got %v", tc.expected, actual)
+			}
+			if actual := testUrlExceptionPaths(expired); !slices.Equal(actual, tc.expired) {
+				t.Errorf("expected expired entries %v, got %v", tc.expired, actual)
+			}
+		})
+	}
+}
+
+func TestRfc3339Validator(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"2025-12-31T23:59:59Z":      true,
+		"2025-12-31T23:59:59+01:00": true,
+		"2025-12-31T23:59:59.5Z":    true,
+		"2025-12-31":                false,
+		"2025-12-31 23:59:59Z":      false,
+		"2025-12-31T23:59:59":       false,
+		"tomorrow":                  false,
+	} {
+		req := validator.StringRequest{Path: path.Root("expires_at"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		rfc3339().ValidateString(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("expected %q validity to be %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &siteIpExceptionResource{}
+	_ resource.ResourceWithConfigure   = &siteIpExceptionResource{}
+	_ resource.ResourceWithImportState = &siteIpExceptionResource{}
+)
+
+// SiteIpExceptionResourceModel maps the resource schema data.
+type SiteIpExceptionResourceModel struct {
+	DomainName  types.String `tfsdk:"domain_name"`
+	Ip          ipRangeValue `tfsdk:"ip"`
+	Comment     types.String `tfsdk:"comment"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// NewSiteIpExceptionResource is a helper function to simplify the provider implementation.
+func NewSiteIpExceptionResource() resource.Resource {
+	return &siteIpExceptionResource{}
+}
+
+// siteIpExceptionResource is the resource implementation.
+type siteIpExceptionResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the resource type name.
+func (r *siteIpExceptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_site_ip_exception"
+}
+
+// Schema defines the schema for the resource.
+func (r *siteIpExceptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS domain name of the site to which the IP exception is added.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Required:    true,
+				CustomType:  ipRangeType{},
+				Description: "IPv4 or IPv6 address or CIDR range never blocked by Ogo Shield.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ipRange(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description associated with this IP list.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last resource updated by Terraform.",
+			},
+		},
+		MarkdownDescription: "Resource `ogo_shield_site_ip_exception` manages a single IP exception " +
+			"of a site, independently of the `ogo_shield_site` resource.\n\n" +
+			"Site `ip_exceptions` attribute must not be managed by `ogo_shield_site` resource " +
+			"(see `manage_exceptions_and_rules` attribute) when this resource is used.\n\n",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *siteIpExceptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ogoProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *siteIpExceptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SiteIpExceptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Add IP exception
+	ipExceptions := []ogosecurity.IpException{}
+	for _, wlip := range site.IpExceptions {
+		if ipRangeEqual(wlip.Ip, plan.Ip.ValueString()) {
+			resp.Diagnostics.AddError(
+				"Error creating site IP exception",
+				"IP exception "+wlip.Ip+" already exists on site "+domainName+", import it to manage it with Terraform.",
+			)
+			return
+		}
+		ipExceptions = append(ipExceptions, wlip)
+	}
+	ipExceptions = append(ipExceptions, ogosecurity.IpException{
+		Ip:      plan.Ip.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	})
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{IpExceptions: &ipExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating site IP exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *siteIpExceptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SiteIpExceptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed site value from Ogo
+	site, err := r.client.GetSite(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+state.DomainName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Find IP exception, remove it from state if it no longer exists
+	var ipException *ogosecurity.IpException
+	for i := range site.IpExceptions {
+		if ipRangeEqual(site.IpExceptions[i].Ip, state.Ip.ValueString()) {
+			ipException = &site.IpExceptions[i]
+			break
+		}
+	}
+	if ipException == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite properties with refreshed state
+	if ipException.Comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(ipException.Comment)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *siteIpExceptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SiteIpExceptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Replace IP exception
+	found := false
+	ipExceptions := []ogosecurity.IpException{}
+	for _, wlip := range site.IpExceptions {
+		if ipRangeEqual(wlip.Ip, plan.Ip.ValueString()) {
+			wlip.Comment = plan.Comment.ValueString()
+			found = true
+		}
+		ipExceptions = append(ipExceptions, wlip)
+	}
+	if !found {
+		ipExceptions = append(ipExceptions, ogosecurity.IpException{
+			Ip:      plan.Ip.ValueString(),
+			Comment: plan.Comment.ValueString(),
+		})
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{IpExceptions: &ipExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating site IP exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *siteIpExceptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SiteIpExceptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Remove IP exception
+	ipExceptions := []ogosecurity.IpException{}
+	for _, wlip := range site.IpExceptions {
+		if !ipRangeEqual(wlip.Ip, state.Ip.ValueString()) {
+			ipExceptions = append(ipExceptions, wlip)
+		}
+	}
+	if len(ipExceptions) == len(site.IpExceptions) {
+		return
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{IpExceptions: &ipExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting site IP exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *siteIpExceptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format is <domain_name>/<ip>
+	domainName, ip, found := strings.Cut(req.ID, "/")
+	if !found || domainName == "" || ip == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID format <domain_name>/<ip>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), ip)...)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSiteIpExceptionResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_ip_exception" "office" {
+  domain_name = ogo_shield_site.foo.domain_name
+  ip          = "192.0.2.0/24"
+  comment     = "Office"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_ip_exception.office", "domain_name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site_ip_exception.office", "ip", "192.0.2.0/24"),
+					resource.TestCheckResourceAttr("ogo_shield_site_ip_exception.office", "comment", "Office"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "0"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ogo_shield_site_ip_exception.office", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "ogo_shield_site_ip_exception.office",
+				ImportStateId:                        "foo.example.com/192.0.2.0/24",
+				ImportStateVerifyIdentifierAttribute: "ip",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+  force_https                 = true
+}
+
+resource "ogo_shield_site_ip_exception" "office" {
+  domain_name = ogo_shield_site.foo.domain_name
+  ip          = "192.0.2.0/24"
+  comment     = "Paris office"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_ip_exception.office", "comment", "Paris office"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "force_https", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceConflictingExceptions(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "abcd"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+  ip_exceptions = [
+    { ip = "192.0.2.1" },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`can't be set when manage_exceptions_and_rules is false`),
+			},
+		},
+	})
+}
+
+func TestAccSiteSubResourcesEmptyComment(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	var steps []resource.TestStep
+	for _, config := range []string{
+		`
+resource "ogo_shield_site_ip_exception" "foo" {
+  domain_name = "foo.example.com"
+  ip          = "192.0.2.1"
+  comment     = ""
+}
+`,
+		`
+resource "ogo_shield_site_url_exception" "foo" {
+  domain_name = "foo.example.com"
+  path        = "^/health"
+  comment     = ""
+}
+`,
+		`
+resource "ogo_shield_site_rule" "foo" {
+  domain_name     = "foo.example.com"
+  action          = "bypass"
+  paths           = ["^/health"]
+  whitelisted_ips = []
+  comment         = ""
+}
+`,
+		`
+resource "ogo_shield_site_rewrite_rule" "foo" {
+  domain_name         = "foo.example.com"
+  rewrite_source      = "^/api/v1/(.*)"
+  rewrite_destination = "/api/v2/$1"
+  comment             = ""
+}
+`,
+	} {
+		steps = append(steps, resource.TestStep{
+			Config:      providerConfig + config,
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`string length must be at least 1`),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
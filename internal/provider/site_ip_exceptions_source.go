@@ -0,0 +1,141 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IpExceptionsSourceModel maps the IP exceptions source file data.
+type IpExceptionsSourceModel struct {
+	File          types.String `tfsdk:"file"`
+	Comment       types.String `tfsdk:"comment"`
+	ContentSha256 types.String `tfsdk:"content_sha256"`
+}
+
+// readIpExceptionsSource reads an IP list file, returning its aggregated IP
+// ranges and the SHA-256 hash of its content.
+func readIpExceptionsSource(file string) ([]string, string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefixes, err := parseIpList(string(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", file, err)
+	}
+	sum := sha256.Sum256(content)
+
+	return formatIpRanges(aggregateIpRanges(prefixes)), hex.EncodeToString(sum[:]), nil
+}
+
+// planIpExceptionsSource checks the IP exceptions source file and sets its
+// planned content hash, so that site is updated when file content changes.
+func planIpExceptionsSource(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var source *IpExceptionsSourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ip_exceptions_source"), &source)...)
+	if resp.Diagnostics.HasError() || source == nil || source.File.IsUnknown() {
+		return
+	}
+
+	_, hash, err := readIpExceptionsSource(source.File.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ip_exceptions_source").AtName("file"),
+			"Error reading IP exceptions source",
+			"Could not read IP list file, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ip_exceptions_source").AtName("content_sha256"), hash)...)
+}
+
+// appendSourceIpExceptions appends IP exceptions of the source file which
+// are not already in ipExceptions, and returns the keys of appended entries
+// to be tracked in private state.
+func (m *SiteResourceModel) appendSourceIpExceptions(ipExceptions []ogosecurity.IpException) ([]ogosecurity.IpException, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.IpExceptionsSource == nil {
+		return ipExceptions, nil, diags
+	}
+
+	file := m.IpExceptionsSource.File.ValueString()
+	ips, hash, err := readIpExceptionsSource(file)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("ip_exceptions_source").AtName("file"),
+			"Error reading IP exceptions source",
+			"Could not read IP list file, unexpected error: "+err.Error(),
+		)
+		return ipExceptions, nil, diags
+	}
+	if !m.IpExceptionsSource.ContentSha256.IsUnknown() && m.IpExceptionsSource.ContentSha256.ValueString() != hash {
+		diags.AddAttributeError(
+			path.Root("ip_exceptions_source").AtName("file"),
+			"IP exceptions source changed",
+			"Content of IP list file "+file+" changed since plan, run plan again to apply it.",
+		)
+		return ipExceptions, nil, diags
+	}
+	m.IpExceptionsSource.ContentSha256 = types.StringValue(hash)
+
+	keys := entryKeys(ipExceptions, ipExceptionKey)
+	sourceKeys := []string{}
+	for _, ip := range ips {
+		if slices.Contains(keys, ip) {
+			continue
+		}
+		ipExceptions = append(ipExceptions, ogosecurity.IpException{
+			Ip:      ip,
+			Comment: m.IpExceptionsSource.Comment.ValueString(),
+		})
+		sourceKeys = append(sourceKeys, ip)
+	}
+
+	return ipExceptions, sourceKeys, diags
+}
+
+// filterSourceIpExceptions removes IP exceptions of the source file from
+// site. Source content hash is cleared if some of them were removed outside
+// of Terraform, so that they are applied again.
+func (m *SiteResourceModel) filterSourceIpExceptions(site *ogosecurity.Site, owned siteOwnedEntries) {
+	if m.IpExceptionsSource == nil {
+		return
+	}
+
+	sourceKeys := map[string]bool{}
+	for _, key := range owned.IpExceptionsSource {
+		sourceKeys[key] = true
+	}
+
+	ipExceptions := []ogosecurity.IpException{}
+	found := 0
+	for _, e := range site.IpExceptions {
+		if sourceKeys[ipExceptionKey(e)] {
+			found++
+			continue
+		}
+		ipExceptions = append(ipExceptions, e)
+	}
+	site.IpExceptions = ipExceptions
+
+	if found < len(sourceKeys) {
+		m.IpExceptionsSource.ContentSha256 = types.StringNull()
+	}
+}
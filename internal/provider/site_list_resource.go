@@ -0,0 +1,183 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &siteListResource{}
+	_ list.ListResourceWithConfigure = &siteListResource{}
+)
+
+// SiteListConfigModel maps the list resource config schema data.
+type SiteListConfigModel struct {
+	ClusterUid types.String   `tfsdk:"cluster_uid"`
+	Status     []types.String `tfsdk:"status"`
+	Tags       []types.String `tfsdk:"tags"`
+}
+
+// NewSiteListResource is a helper function to simplify the provider implementation.
+func NewSiteListResource() list.ListResource {
+	return &siteListResource{}
+}
+
+// siteListResource is the list resource implementation.
+type siteListResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the list resource type name.
+func (r *siteListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_site"
+}
+
+// ListResourceConfigSchema defines the schema for the list resource config.
+func (r *siteListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List Ogo Shield sites of the organization.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_uid": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list sites protected by this cluster.",
+			},
+			"status": schema.ListAttribute{
+				Optional:    true,
+				Description: "Only list sites with `status` in these values, e.g. `[\"DNS_ERROR\", \"OFFLINE\"]`.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(
+						stringvalidator.OneOf("CREATED", "DNS_ERROR", "ONLINE", "LE_CERT", "CUST_CERT", "LE_EXP", "CUST_EXP", "OFFLINE"),
+					),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				Description: "Only list sites having all these tags.",
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *siteListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ogosecurity.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected list resource configure type",
+			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// List streams sites matching the list resource config.
+func (r *siteListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config SiteListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	sites := r.client.AllSites()
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for site, err := range sites {
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError(
+					"Error listing Ogo sites",
+					"Could not list Ogo sites, unexpected error: "+err.Error(),
+				)
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			if !config.matches(site) {
+				continue
+			}
+
+			if !push(r.result(ctx, req, site)) {
+				return
+			}
+
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+// result returns the list result of a site, with its full resource object
+// if requested.
+func (r *siteListResource) result(ctx context.Context, req list.ListRequest, site *ogosecurity.Site) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = site.DomainName
+
+	identity := SiteResourceIdentityModel{
+		Organization: types.StringValue(r.client.Organization),
+		DomainName:   types.StringValue(site.DomainName),
+	}
+	result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+	if req.IncludeResource {
+		state := SiteResourceModel{
+			DomainName: types.StringValue(site.DomainName),
+			Timeouts:   siteTimeoutsNull(),
+		}
+		result.Diagnostics.Append(state.read(ctx, site, siteOwnedEntries{})...)
+		if !result.Diagnostics.HasError() {
+			result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+		}
+	}
+
+	return result
+}
+
+// matches returns true if site matches all filters of the config.
+func (m *SiteListConfigModel) matches(site *ogosecurity.Site) bool {
+	if !m.ClusterUid.IsNull() && m.ClusterUid.ValueString() != site.Cluster.Uid {
+		return false
+	}
+	if len(m.Status) > 0 && !containsString(m.Status, site.Status) {
+		return false
+	}
+	for _, tag := range m.Tags {
+		if !slices.Contains(site.Tags, tag.ValueString()) {
+			return false
+		}
+	}
+
+	return true
+}
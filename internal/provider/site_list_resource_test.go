@@ -0,0 +1,91 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSiteListResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	organization := os.Getenv("OGO_ORGANIZATION")
+	if organization == "" {
+		t.Errorf("OGO_ORGANIZATION must be set")
+	}
+
+	identity := map[string]knownvalue.Check{
+		"organization": knownvalue.StringExact(organization),
+		"domain_name":  knownvalue.StringExact("foo.example.com"),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			// Create site to list
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  tags          = ["list-test"]
+}
+`,
+			},
+			// List sites with filters
+			{
+				Query: true,
+				Config: providerConfig + `
+list "ogo_shield_site" "tagged" {
+  provider         = ogo
+  include_resource = true
+
+  config {
+    cluster_uid = "` + clusterUid + `"
+    tags        = ["list-test"]
+  }
+}
+
+list "ogo_shield_site" "offline" {
+  provider = ogo
+
+  config {
+    tags   = ["list-test"]
+    status = ["OFFLINE"]
+  }
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("ogo_shield_site.tagged", 1),
+					querycheck.ExpectIdentity("ogo_shield_site.tagged", identity),
+					querycheck.ExpectResourceDisplayName("ogo_shield_site.tagged", queryfilter.ByResourceIdentity(identity), knownvalue.StringExact("foo.example.com")),
+					querycheck.ExpectResourceKnownValues("ogo_shield_site.tagged", queryfilter.ByResourceIdentity(identity), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("cluster_uid"), KnownValue: knownvalue.StringExact(clusterUid)},
+						{Path: tfjsonpath.New("origin_server"), KnownValue: knownvalue.StringExact("172.18.1.12")},
+						{Path: tfjsonpath.New("origin_port"), KnownValue: knownvalue.Null()},
+						{Path: tfjsonpath.New("manage_exceptions_and_rules"), KnownValue: knownvalue.Bool(true)},
+					}),
+					querycheck.ExpectLength("ogo_shield_site.offline", 0),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sync"
+)
+
+// siteLocks serializes read-modify-write updates of a site, so concurrent
+// updates of the same site from different resources don't lose changes.
+var siteLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{
+	locks: map[string]*sync.Mutex{},
+}
+
+// lockSite locks the site identified by its domain name and returns the
+// function to be called to unlock it. Only the first call of the returned
+// function unlocks the site.
+func lockSite(domainName string) func() {
+	siteLocks.Lock()
+	lock, ok := siteLocks.locks[domainName]
+	if !ok {
+		lock = &sync.Mutex{}
+		siteLocks.locks[domainName] = lock
+	}
+	siteLocks.Unlock()
+
+	lock.Lock()
+
+	return sync.OnceFunc(lock.Unlock)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Site collections management modes.
+const (
+	managementAuthoritative = "authoritative"
+	managementAdditive      = "additive"
+)
+
+// siteManagementDescription returns the description of a collection
+// management mode attribute.
+func siteManagementDescription(attribute string, label string) string {
+	return "Management mode of `" + attribute + "` (default: **authoritative**). Supported values:\n" +
+		"  * **authoritative**: Terraform replaces site " + label + " with the configured ones\n" +
+		"  * **additive**: Terraform only manages the configured " + label + ", other " + label + " added outside of Terraform are kept. " +
+		"Terraform managed " + label + " are placed after the other ones."
+}
+
+// siteOwnedEntriesKey is the private state key storing entries owned by
+// Terraform in additive collections.
+const siteOwnedEntriesKey = "owned_entries"
+
+// privateState is implemented by private state of requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// siteOwnedEntries lists the keys of entries owned by Terraform in additive
+// collections of a site.
+type siteOwnedEntries struct {
+	IpExceptions  []string `json:"ip_exceptions,omitempty"`
+	UrlExceptions []string `json:"url_exceptions,omitempty"`
+	RewriteRules  []string `json:"rewrite_rules,omitempty"`
+	Rules         []string `json:"rules,omitempty"`
+
+	// IpExceptionsSource lists the keys of IP exceptions added from the
+	// source file, whatever the management mode of IP exceptions.
+	IpExceptionsSource []string `json:"ip_exceptions_source,omitempty"`
+}
+
+// getSiteOwnedEntries reads owned entries from private state.
+func getSiteOwnedEntries(ctx context.Context, private privateState) (siteOwnedEntries, diag.Diagnostics) {
+	var owned siteOwnedEntries
+
+	data, diags := private.GetKey(ctx, siteOwnedEntriesKey)
+	if diags.HasError() || len(data) == 0 {
+		return owned, diags
+	}
+
+	if err := json.Unmarshal(data, &owned); err != nil {
+		diags.AddError(
+			"Error reading private state",
+			"Could not decode site owned entries, unexpected error: "+err.Error(),
+		)
+	}
+
+	return owned, diags
+}
+
+// setSiteOwnedEntries stores owned entries in private state.
+func setSiteOwnedEntries(ctx context.Context, private privateState, owned siteOwnedEntries) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data, err := json.Marshal(owned)
+	if err != nil {
+		diags.AddError(
+			"Error writing private state",
+			"Could not encode site owned entries, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, siteOwnedEntriesKey, data)
+}
+
+// siteOwnedEntriesFrom returns the keys of additive collections entries of site.
+func (m *SiteResourceModel) siteOwnedEntriesFrom(site ogosecurity.Site) siteOwnedEntries {
+	var owned siteOwnedEntries
+
+	if m.IpExceptionsManagement.ValueString() == managementAdditive {
+		owned.IpExceptions = entryKeys(site.IpExceptions, ipExceptionKey)
+	}
+	if m.UrlExceptionsManagement.ValueString() == managementAdditive {
+		owned.UrlExceptions = entryKeys(site.UrlExceptions, urlExceptionKey)
+	}
+	if m.RewriteRulesManagement.ValueString() == managementAdditive {
+		owned.RewriteRules = entryKeys(site.RewriteRules, rewriteRuleKey)
+	}
+	if m.RulesManagement.ValueString() == managementAdditive {
+		owned.Rules = entryKeys(site.Rules, ruleKey)
+	}
+
+	return owned
+}
+
+// filterOwnedEntries removes entries not owned by Terraform from additive
+// collections of site.
+func (m *SiteResourceModel) filterOwnedEntries(site *ogosecurity.Site, owned siteOwnedEntries) {
+	if m.IpExceptionsManagement.ValueString() == managementAdditive {
+		site.IpExceptions = ownedEntries(site.IpExceptions, owned.IpExceptions, ipExceptionKey)
+	}
+	if m.UrlExceptionsManagement.ValueString() == managementAdditive {
+		site.UrlExceptions = ownedEntries(site.UrlExceptions, owned.UrlExceptions, urlExceptionKey)
+	}
+	if m.RewriteRulesManagement.ValueString() == managementAdditive {
+		site.RewriteRules = ownedEntries(site.RewriteRules, owned.RewriteRules, rewriteRuleKey)
+	}
+	if m.RulesManagement.ValueString() == managementAdditive {
+		site.Rules = ownedEntries(site.Rules, owned.Rules, ruleKey)
+	}
+}
+
+// mergeOwnedEntries merges planned entries of additive collections of site
+// with entries of current site which are not owned by Terraform.
+func (m *SiteResourceModel) mergeOwnedEntries(site *ogosecurity.Site, current ogosecurity.Site, owned siteOwnedEntries) {
+	if m.IpExceptionsManagement.ValueString() == managementAdditive {
+		site.IpExceptions = mergeEntries(current.IpExceptions, site.IpExceptions, owned.IpExceptions, ipExceptionKey)
+	}
+	if m.UrlExceptionsManagement.ValueString() == managementAdditive {
+		site.UrlExceptions = mergeEntries(current.UrlExceptions, site.UrlExceptions, owned.UrlExceptions, urlExceptionKey)
+	}
+	if m.RewriteRulesManagement.ValueString() == managementAdditive {
+		site.RewriteRules = mergeEntries(current.RewriteRules, site.RewriteRules, owned.RewriteRules, rewriteRuleKey)
+	}
+	if m.RulesManagement.ValueString() == managementAdditive {
+		site.Rules = mergeEntries(current.Rules, site.Rules, owned.Rules, ruleKey)
+	}
+}
+
+// hasAdditiveCollection returns true if any collection is in additive mode.
+func (m *SiteResourceModel) hasAdditiveCollection() bool {
+	for _, mode := range []string{
+		m.IpExceptionsManagement.ValueString(),
+		m.UrlExceptionsManagement.ValueString(),
+		m.RewriteRulesManagement.ValueString(),
+		m.RulesManagement.ValueString(),
+	} {
+		if mode == managementAdditive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ipExceptionKey identifies an IP exception by its canonical IP range, so
+// that different notations of an IP range are the same entry.
+func ipExceptionKey(e ogosecurity.IpException) string {
+	return canonicalIpRange(e.Ip)
+}
+
+func urlExceptionKey(e ogosecurity.UrlException) string {
+	return e.Path
+}
+
+func rewriteRuleKey(e ogosecurity.RewriteRule) string {
+	return e.RewriteSource
+}
+
+// ruleKey identifies a rule by its sorted paths.
+func ruleKey(e ogosecurity.Rule) string {
+	paths := append([]string{}, e.Paths...)
+	sort.Strings(paths)
+	return strings.Join(paths, ",")
+}
+
+// entryKeys returns the keys of entries.
+func entryKeys[T any](entries []T, key func(T) string) []string {
+	keys := []string{}
+	for _, entry := range entries {
+		keys = append(keys, key(entry))
+	}
+
+	return keys
+}
+
+// ownedEntries returns entries whose key is owned.
+func ownedEntries[T any](entries []T, owned []string, key func(T) string) []T {
+	ownedKeys := make(map[string]bool, len(owned))
+	for _, k := range owned {
+		ownedKeys[k] = true
+	}
+
+	result := []T{}
+	for _, entry := range entries {
+		if ownedKeys[key(entry)] {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+// mergeEntries keeps current entries neither owned nor planned, in their
+// original order, followed by planned entries.
+func mergeEntries[T any](current []T, planned []T, owned []string, key func(T) string) []T {
+	managedKeys := make(map[string]bool, len(owned)+len(planned))
+	for _, k := range owned {
+		managedKeys[k] = true
+	}
+	for _, entry := range planned {
+		managedKeys[key(entry)] = true
+	}
+
+	result := []T{}
+	for _, entry := range current {
+		if !managedKeys[key(entry)] {
+			result = append(result, entry)
+		}
+	}
+
+	return append(result, planned...)
+}
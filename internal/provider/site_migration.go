@@ -0,0 +1,169 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// siteMigrationRequired returns true if site must be moved to another
+// cluster or contract.
+func siteMigrationRequired(plan SiteResourceModel, state SiteResourceModel) bool {
+	if !plan.ClusterUid.Equal(state.ClusterUid) {
+		return true
+	}
+
+	return !plan.ContractNumber.IsNull() && !plan.ContractNumber.IsUnknown() && !plan.ContractNumber.Equal(state.ContractNumber)
+}
+
+// siteMigrationDiagnostics returns an error if site must be migrated while
+// deletion_protection is enabled, and warns that site is deleted then created
+// again otherwise. on_destroy is ignored since migration always deletes the
+// site from Ogo.
+func siteMigrationDiagnostics(plan SiteResourceModel, state SiteResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.ClusterUid.IsUnknown() || !siteMigrationRequired(plan, state) {
+		return diags
+	}
+
+	target := "cluster " + plan.ClusterUid.ValueString()
+	if !plan.ContractNumber.IsNull() && !plan.ContractNumber.IsUnknown() {
+		target += " and contract " + plan.ContractNumber.ValueString()
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("cluster_uid"),
+			"Site deletion protection enabled",
+			"Site "+state.DomainName.ValueString()+" can't be migrated to "+target+" while deletion_protection is enabled, since migration deletes the site and creates it again. "+
+				"Set deletion_protection to false and apply before migrating it.",
+		)
+		return diags
+	}
+
+	detail := "Site " + state.DomainName.ValueString() + " will be deleted and created again on " + target + ". " +
+		"Site is unavailable between its deletion and its creation."
+	if !plan.ClusterUid.Equal(state.ClusterUid) {
+		detail += " Update DNS records planned in dns_records once applied."
+	}
+	diags.AddAttributeWarning(path.Root("cluster_uid"), "Site migration", detail)
+
+	return diags
+}
+
+// migrateSite moves current site to target cluster and contract. Site is
+// deleted then created again with target configuration, and restored with
+// its current configuration if creation fails.
+func (r *siteResource) migrateSite(current *ogosecurity.Site, target ogosecurity.Site) (*ogosecurity.Site, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Customer certificate can only be carried over if its P12 is configured
+	if current.ActiveCustomerCertificate != nil && current.ActiveCustomerCertificate.Hash != "" && target.ActiveCustomerCertificate == nil {
+		diags.AddError(
+			"Error migrating site",
+			"Site "+current.DomainName+" customer certificate "+current.ActiveCustomerCertificate.Cn+" can't be carried over to the target cluster "+
+				"because it is not managed by Terraform. Configure active_customer_certificate before migrating the site.",
+		)
+		return nil, diags
+	}
+
+	err := r.client.DeleteSite(current.DomainName)
+	if err != nil {
+		diags.AddError(
+			"Error migrating site",
+			"Could not delete site "+current.DomainName+" from cluster "+current.Cluster.Uid+", unexpected error: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	site, err := r.client.CreateSite(target)
+	if err == nil {
+		return site, diags
+	}
+
+	// Rollback to current configuration
+	restore := *current
+	restore.ActiveCustomerCertificate = target.ActiveCustomerCertificate
+	if _, rollbackErr := r.client.CreateSite(restore); rollbackErr != nil {
+		diags.AddError(
+			"Error migrating site",
+			"Could not create site "+current.DomainName+" on cluster "+target.Cluster.Uid+", unexpected error: "+err.Error()+"\n\n"+
+				"Site could not be restored on cluster "+current.Cluster.Uid+" and no longer exists, unexpected error: "+rollbackErr.Error(),
+		)
+		return nil, diags
+	}
+
+	diags.AddError(
+		"Error migrating site",
+		"Could not create site "+current.DomainName+" on cluster "+target.Cluster.Uid+", unexpected error: "+err.Error()+"\n\n"+
+			"Site has been restored on cluster "+current.Cluster.Uid+".",
+	)
+
+	return nil, diags
+}
+
+// planSiteMigration shows target cluster entrypoints and DNS records in
+// plan when site is moved to another cluster. Migration itself is warned
+// about by siteMigrationDiagnostics.
+func (r *siteResource) planSiteMigration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var domainName, planClusterUid, stateClusterUid, cdn types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain_name"), &domainName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster_uid"), &planClusterUid)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cdn"), &cdn)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("cluster_uid"), &stateClusterUid)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planClusterUid.IsUnknown() || planClusterUid.Equal(stateClusterUid) || cdn.IsUnknown() {
+		return
+	}
+
+	clusters, err := r.client.GetAllClusters()
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("cluster_uid"),
+			"Unable to retrieve target cluster",
+			"Could not retrieve clusters from Ogo, new DNS records will only be known after apply: "+err.Error(),
+		)
+		return
+	}
+
+	var target *ogosecurity.Cluster
+	for i := range clusters {
+		if clusters[i].Uid == planClusterUid.ValueString() {
+			target = &clusters[i]
+			break
+		}
+	}
+	if target == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cluster_uid"),
+			"Unknown cluster",
+			fmt.Sprintf("Cluster %q doesn't exist. List of available clusters can be retrieved from `ogo_shield_clusters` data source.", planClusterUid.ValueString()),
+		)
+		return
+	}
+
+	site := &ogosecurity.Site{
+		DomainName: domainName.ValueString(),
+		Cluster:    *target,
+		Cdn:        cdn.ValueStringPointer(),
+	}
+	dnsRecords, diags := siteDnsRecordsValue(ctx, site)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cluster_entrypoint_4"), target.Entrypoint4)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cluster_entrypoint_6"), target.Entrypoint6)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cluster_entrypoint_cdn"), target.EntrypointCdn)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dns_records"), dnsRecords)...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testSiteMigrationModel(clusterUid string, contractNumber string, deletionProtection bool) SiteResourceModel {
+	return SiteResourceModel{
+		DomainName:         types.StringValue("www.example.com"),
+		ClusterUid:         types.StringValue(clusterUid),
+		ContractNumber:     types.StringValue(contractNumber),
+		DeletionProtection: types.BoolValue(deletionProtection),
+		OnDestroy:          types.StringValue(siteOnDestroyAbandon),
+	}
+}
+
+func TestSiteMigrationDiagnostics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		plan     SiteResourceModel
+		state    SiteResourceModel
+		errors   int
+		warnings int
+	}{
+		"unchanged": {
+			plan:  testSiteMigrationModel("cluster-a", "C1", true),
+			state: testSiteMigrationModel("cluster-a", "C1", true),
+		},
+		"cluster change": {
+			plan:     testSiteMigrationModel("cluster-b", "C1", false),
+			state:    testSiteMigrationModel("cluster-a", "C1", false),
+			warnings: 1,
+		},
+		"contract change": {
+			plan:     testSiteMigrationModel("cluster-a", "C2", false),
+			state:    testSiteMigrationModel("cluster-a", "C1", false),
+			warnings: 1,
+		},
+		"cluster change with deletion protection": {
+			plan:   testSiteMigrationModel("cluster-b", "C1", true),
+			state:  testSiteMigrationModel("cluster-a", "C1", true),
+			errors: 1,
+		},
+		"contract change with deletion protection": {
+			plan:   testSiteMigrationModel("cluster-a", "C2", true),
+			state:  testSiteMigrationModel("cluster-a", "C1", true),
+			errors: 1,
+		},
+		"unknown cluster": {
+			plan: SiteResourceModel{
+				ClusterUid:     types.StringUnknown(),
+				ContractNumber: types.StringValue("C1"),
+			},
+			state: testSiteMigrationModel("cluster-a", "C1", true),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := siteMigrationDiagnostics(tc.plan, tc.state)
+			if diags.ErrorsCount() != tc.errors || diags.WarningsCount() != tc.warnings {
+				t.Errorf("expected %d errors and %d warnings, got %v", tc.errors, tc.warnings, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Rules and rewrite rules are applied by Ogo Shield in order of the site
+// lists. An optional priority lets rules be declared in any order, so that
+// inserting a rule doesn't shift all the following ones in plans: entries
+// are sent to Ogo sorted by priority and read back in order of declaration.
+
+// priorityOrder returns indexes of entries in order of application: entries
+// with a priority first, by increasing priority, then entries without
+// priority. Entries with the same priority are kept in order of declaration.
+func priorityOrder[T any](entries []T, priority func(T) *int64) []int {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		priorityA, priorityB := priority(entries[a]), priority(entries[b])
+		switch {
+		case priorityA == nil && priorityB == nil:
+			return 0
+		case priorityA == nil:
+			return 1
+		case priorityB == nil:
+			return -1
+		default:
+			return cmp.Compare(*priorityA, *priorityB)
+		}
+	})
+
+	return order
+}
+
+// sortByPriority returns entries in order of application.
+func sortByPriority[T any](entries []T, priority func(T) *int64) []T {
+	sorted := make([]T, 0, len(entries))
+	for _, i := range priorityOrder(entries, priority) {
+		sorted = append(sorted, entries[i])
+	}
+
+	return sorted
+}
+
+// restorePriorities restores priorities of entries read from Ogo, in order
+// of application, from prior entries with the same key, and returns them in
+// order of declaration of prior entries, followed by unknown entries.
+// Entries are kept in Ogo order if they were reordered outside of Terraform,
+// so that the plan shows the change.
+func restorePriorities[T any](entries []T, prior []T, key func(T) string, priority func(T) *int64, setPriority func(*T, *int64)) []T {
+	if !slices.ContainsFunc(prior, func(e T) bool { return priority(e) != nil }) {
+		return entries
+	}
+
+	// Position of prior entries in order of application
+	position := make([]int, len(prior))
+	for k, i := range priorityOrder(prior, priority) {
+		position[i] = k
+	}
+
+	priorIndexes := map[string][]int{}
+	for i, e := range prior {
+		priorIndexes[key(e)] = append(priorIndexes[key(e)], i)
+	}
+
+	// Match entries with prior entries, in order of application
+	entryIndexes := map[int]int{}
+	unknown := []int{}
+	ordered := true
+	last := -1
+	for i, e := range entries {
+		indexes := priorIndexes[key(e)]
+		if len(indexes) == 0 {
+			unknown = append(unknown, i)
+			continue
+		}
+		p := indexes[0]
+		priorIndexes[key(e)] = indexes[1:]
+
+		setPriority(&entries[i], priority(prior[p]))
+		entryIndexes[p] = i
+		if position[p] < last {
+			ordered = false
+		}
+		last = position[p]
+	}
+	if !ordered {
+		return entries
+	}
+
+	result := make([]T, 0, len(entries))
+	for p := range prior {
+		if i, ok := entryIndexes[p]; ok {
+			result = append(result, entries[i])
+		}
+	}
+	for _, i := range unknown {
+		result = append(result, entries[i])
+	}
+
+	return result
+}
+
+// ruleModelKey identifies a rule by its sorted paths.
+func ruleModelKey(m RuleModel) string {
+	paths := []string{}
+	for _, p := range m.Paths {
+		paths = append(paths, p.ValueString())
+	}
+	sort.Strings(paths)
+
+	return strings.Join(paths, ",")
+}
+
+func ruleModelPriority(m RuleModel) *int64 {
+	return m.Priority.ValueInt64Pointer()
+}
+
+func setRuleModelPriority(m *RuleModel, priority *int64) {
+	m.Priority = types.Int64PointerValue(priority)
+}
+
+// rewriteRuleModelKey identifies a rewrite rule by its source.
+func rewriteRuleModelKey(m RewriteRuleModel) string {
+	return m.RewriteSource.ValueString()
+}
+
+func rewriteRuleModelPriority(m RewriteRuleModel) *int64 {
+	return m.Priority.ValueInt64Pointer()
+}
+
+func setRewriteRuleModelPriority(m *RewriteRuleModel, priority *int64) {
+	m.Priority = types.Int64PointerValue(priority)
+}
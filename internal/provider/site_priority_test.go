@@ -0,0 +1,112 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testRewriteRuleModel(source string, priority *int64) RewriteRuleModel {
+	return RewriteRuleModel{
+		Active:             types.BoolValue(true),
+		Priority:           types.Int64PointerValue(priority),
+		RewriteSource:      types.StringValue(source),
+		RewriteDestination: types.StringValue("/"),
+	}
+}
+
+func testPriority(priority int64) *int64 {
+	return &priority
+}
+
+func testRewriteSources(rules []RewriteRuleModel) []string {
+	sources := []string{}
+	for _, rule := range rules {
+		sources = append(sources, rule.RewriteSource.ValueString())
+	}
+
+	return sources
+}
+
+func TestPriorityOrder(t *testing.T) {
+	rules := []RewriteRuleModel{
+		testRewriteRuleModel("^/a", nil),
+		testRewriteRuleModel("^/b", testPriority(20)),
+		testRewriteRuleModel("^/c", nil),
+		testRewriteRuleModel("^/d", testPriority(10)),
+		testRewriteRuleModel("^/e", testPriority(20)),
+	}
+
+	expected := []int{3, 1, 4, 0, 2}
+	if actual := priorityOrder(rules, rewriteRuleModelPriority); !slices.Equal(actual, expected) {
+		t.Errorf("expected order %v, got %v", expected, actual)
+	}
+}
+
+func TestRestorePriorities(t *testing.T) {
+	prior := []RewriteRuleModel{
+		testRewriteRuleModel("^/a", testPriority(20)),
+		testRewriteRuleModel("^/b", testPriority(30)),
+		testRewriteRuleModel("^/c", testPriority(10)),
+	}
+
+	for name, tc := range map[string]struct {
+		read     []string
+		expected []string
+	}{
+		"in order of priority": {
+			read:     []string{"^/c", "^/a", "^/b"},
+			expected: []string{"^/a", "^/b", "^/c"},
+		},
+		"removed and added outside of Terraform": {
+			read:     []string{"^/x", "^/c", "^/b"},
+			expected: []string{"^/b", "^/c", "^/x"},
+		},
+		"reordered outside of Terraform": {
+			read:     []string{"^/a", "^/c", "^/b"},
+			expected: []string{"^/a", "^/c", "^/b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			read := []RewriteRuleModel{}
+			for _, source := range tc.read {
+				read = append(read, testRewriteRuleModel(source, nil))
+			}
+
+			restored := restorePriorities(read, prior, rewriteRuleModelKey, rewriteRuleModelPriority, setRewriteRuleModelPriority)
+			if actual := testRewriteSources(restored); !slices.Equal(actual, tc.expected) {
+				t.Errorf("expected rewrite rules %v, got %v", tc.expected, actual)
+			}
+			for _, rule := range restored {
+				i := slices.IndexFunc(prior, func(p RewriteRuleModel) bool { return p.RewriteSource.Equal(rule.RewriteSource) })
+				if i >= 0 && !rule.Priority.Equal(prior[i].Priority) {
+					t.Errorf("expected rewrite rule %s priority %s, got %s", rule.RewriteSource, prior[i].Priority, rule.Priority)
+				}
+				if i < 0 && !rule.Priority.IsNull() {
+					t.Errorf("expected rewrite rule %s without priority, got %s", rule.RewriteSource, rule.Priority)
+				}
+			}
+		})
+	}
+}
+
+func TestRestorePrioritiesWithoutPriority(t *testing.T) {
+	prior := []RewriteRuleModel{
+		testRewriteRuleModel("^/a", nil),
+		testRewriteRuleModel("^/b", nil),
+	}
+	read := []RewriteRuleModel{
+		testRewriteRuleModel("^/b", nil),
+		testRewriteRuleModel("^/a", nil),
+	}
+
+	expected := []string{"^/b", "^/a"}
+	restored := restorePriorities(read, prior, rewriteRuleModelKey, rewriteRuleModelPriority, setRewriteRuleModelPriority)
+	if actual := testRewriteSources(restored); !slices.Equal(actual, expected) {
+		t.Errorf("expected rewrite rules in Ogo order %v, got %v", expected, actual)
+	}
+}
@@ -13,11 +13,15 @@ import (
 
 	ogosecurity "terraform-provider-ogo/internal/ogo"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
@@ -27,14 +31,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Site destruction actions.
+const (
+	siteOnDestroyDelete  = "delete"
+	siteOnDestroyAbandon = "abandon"
+)
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &siteResource{}
-	_ resource.ResourceWithConfigure   = &siteResource{}
-	_ resource.ResourceWithImportState = &siteResource{}
+	_ resource.Resource                   = &siteResource{}
+	_ resource.ResourceWithConfigure      = &siteResource{}
+	_ resource.ResourceWithImportState    = &siteResource{}
+	_ resource.ResourceWithModifyPlan     = &siteResource{}
+	_ resource.ResourceWithValidateConfig = &siteResource{}
+	_ resource.ResourceWithUpgradeState   = &siteResource{}
+	_ resource.ResourceWithIdentity       = &siteResource{}
 )
 
 // SiteResourceModel maps the resource schema data.
@@ -44,6 +59,7 @@ type SiteResourceModel struct {
 	ClusterEntrypoint4        types.String                    `tfsdk:"cluster_entrypoint_4"`
 	ClusterEntrypoint6        types.String                    `tfsdk:"cluster_entrypoint_6"`
 	ClusterEntrypointCdn      types.String                    `tfsdk:"cluster_entrypoint_cdn"`
+	DnsRecords                types.List                      `tfsdk:"dns_records"`
 	ContractNumber            types.String                    `tfsdk:"contract_number"`
 	OriginServer              types.String                    `tfsdk:"origin_server"`
 	OriginScheme              types.String                    `tfsdk:"origin_scheme"`
@@ -66,10 +82,20 @@ type SiteResourceModel struct {
 	ActiveCustomerCertificate *ActiveCustomerCertificateModel `tfsdk:"active_customer_certificate"`
 	BlacklistedCountries      []types.String                  `tfsdk:"blacklisted_countries"`
 	IpExceptions              []IpExceptionModel              `tfsdk:"ip_exceptions"`
+	IpExceptionsSource        *IpExceptionsSourceModel        `tfsdk:"ip_exceptions_source"`
 	UrlExceptions             []UrlExceptionModel             `tfsdk:"url_exceptions"`
 	RewriteRules              []RewriteRuleModel              `tfsdk:"rewrite_rules"`
 	Rules                     []RuleModel                     `tfsdk:"rules"`
 	Tags                      []types.String                  `tfsdk:"tags"`
+	ManageExceptionsAndRules  types.Bool                      `tfsdk:"manage_exceptions_and_rules"`
+	IpExceptionsManagement    types.String                    `tfsdk:"ip_exceptions_management"`
+	UrlExceptionsManagement   types.String                    `tfsdk:"url_exceptions_management"`
+	RewriteRulesManagement    types.String                    `tfsdk:"rewrite_rules_management"`
+	RulesManagement           types.String                    `tfsdk:"rules_management"`
+	WaitFor                   *SiteWaitForModel               `tfsdk:"wait_for"`
+	DeletionProtection        types.Bool                      `tfsdk:"deletion_protection"`
+	OnDestroy                 types.String                    `tfsdk:"on_destroy"`
+	Timeouts                  timeouts.Value                  `tfsdk:"timeouts"`
 	LastUpdated               types.String                    `tfsdk:"last_updated"`
 }
 
@@ -84,6 +110,7 @@ type ActiveCustomerCertificateModel struct {
 
 type RewriteRuleModel struct {
 	Active             types.Bool   `tfsdk:"active"`
+	Priority           types.Int64  `tfsdk:"priority"`
 	Comment            types.String `tfsdk:"comment"`
 	RewriteSource      types.String `tfsdk:"rewrite_source"`
 	RewriteDestination types.String `tfsdk:"rewrite_destination"`
@@ -93,19 +120,29 @@ type RuleModel struct {
 	Active         types.Bool     `tfsdk:"active"`
 	Action         types.String   `tfsdk:"action"`
 	Cache          types.Bool     `tfsdk:"cache"`
+	Priority       types.Int64    `tfsdk:"priority"`
 	Comment        types.String   `tfsdk:"comment"`
 	Paths          []types.String `tfsdk:"paths"`
-	WhitelistedIps []types.String `tfsdk:"whitelisted_ips"`
+	WhitelistedIps []ipRangeValue `tfsdk:"whitelisted_ips"`
+	ExpiresAt      types.String   `tfsdk:"expires_at"`
 }
 
 type UrlExceptionModel struct {
-	Path    types.String `tfsdk:"path"`
-	Comment types.String `tfsdk:"comment"`
+	Path      types.String `tfsdk:"path"`
+	Comment   types.String `tfsdk:"comment"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
 }
 
 type IpExceptionModel struct {
-	Ip      types.String `tfsdk:"ip"`
-	Comment types.String `tfsdk:"comment"`
+	Ip        ipRangeValue `tfsdk:"ip"`
+	Comment   types.String `tfsdk:"comment"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// SiteResourceIdentityModel maps the resource identity data.
+type SiteResourceIdentityModel struct {
+	Organization types.String `tfsdk:"organization"`
+	DomainName   types.String `tfsdk:"domain_name"`
 }
 
 // NewSiteResource is a helper function to simplify the provider implementation.
@@ -115,7 +152,8 @@ func NewSiteResource() resource.Resource {
 
 // siteResource is the resource implementation.
 type siteResource struct {
-	client *ogosecurity.Client
+	client           *ogosecurity.Client
+	strictValidation bool
 }
 
 // Metadata returns the resource type name.
@@ -124,8 +162,9 @@ func (r *siteResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *siteResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: siteResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
 				Required:    true,
@@ -135,11 +174,11 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"cluster_uid": schema.StringAttribute{
-				Required:    true,
-				Description: "Cluster UID on which site is deployed (**force site recreation if modified**). List of available cluster and associated UID can be retrieved from `ogo_shield_clusters` data source.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Required: true,
+				Description: "Cluster UID on which site is deployed. List of available cluster and associated UID can be retrieved from `ogo_shield_clusters` data source. " +
+					"If modified, site is migrated to the new cluster with its configuration and customer certificate, and restored on the previous cluster if migration fails. " +
+					"Site is deleted then created again during migration, so it is unavailable in between, and DNS records must then be updated. " +
+					"Migration is refused while `deletion_protection` is enabled.",
 			},
 			"cluster_entrypoint_4": schema.StringAttribute{
 				Computed:    true,
@@ -153,11 +192,38 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:    true,
 				Description: "CDN entrypoint to which the site DNS record can be configured.",
 			},
+			"dns_records": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "DNS records to be configured for the site domain name to redirect to Ogo Shield cluster: " +
+					"a **CNAME** record to the CDN entrypoint if `cdn` is set, **A** and **AAAA** records to cluster entrypoints otherwise.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "DNS record name.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "DNS record type (**A**, **AAAA** or **CNAME**).",
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "DNS record value.",
+						},
+						"ttl_hint": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Recommended DNS record TTL in seconds.",
+						},
+					},
+				},
+			},
 			"contract_number": schema.StringAttribute{
-				Optional:    true,
-				Description: "Contract number to which the site is attached, only required if multiple contracts exist for this organization. List of available contracts can be retrieved from `ogo_shield_contrats` data source.",
+				Optional: true,
+				Computed: true,
+				Description: "Contract number to which the site is attached, only required if multiple contracts exist for this organization. List of available contracts can be retrieved from `ogo_shield_contrats` data source. " +
+					"If modified, site is migrated to the new contract like on `cluster_uid` change. If not set, the contract assigned by Ogo is kept.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"origin_server": schema.StringAttribute{
@@ -174,8 +240,9 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"origin_port": schema.Int32Attribute{
-				Optional:    true,
-				Description: "Port to be used to access the origin server. Must be defined only if different from standard HTTP port 443 or 80, otherwise let Ogo choose the correct port.",
+				Optional: true,
+				Description: "Port to be used to access the origin server. Must be defined only if different from standard HTTP port 443 or 80, otherwise let Ogo choose the correct port. " +
+					"Standard port of `origin_scheme` is not stored in state unless configured.",
 				Validators: []validator.Int32{
 					int32validator.Between(1, 65535),
 				},
@@ -294,8 +361,9 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"active_customer_certificate": schema.SingleNestedAttribute{
-				Optional:    true,
-				Description: "P12/PFX certificate to be used for this site.",
+				Optional: true,
+				Description: "P12/PFX certificate to be used for this site. " +
+					"Not populated on import as P12 content and password can't be read back from Ogo, configure it after import to manage the certificate.",
 				Attributes: map[string]schema.Attribute{
 					"cn": schema.StringAttribute{
 						Computed:    true,
@@ -328,8 +396,11 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"blacklisted_countries": schema.SetAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "List of countries to blacklist.",
+				Description: "List of ISO 3166-1 alpha-2 country codes to blacklist. List of available countries can be retrieved from `ogo_shield_countries` data source.",
 				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(countryCode()),
+				},
 				Default: setdefault.StaticValue(
 					types.SetValueMust(
 						types.StringType,
@@ -340,11 +411,11 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"brain_overrides": schema.MapAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "List of brain parameters to override",
-				ElementType: types.Float64Type,
+				Description: "List of brain parameters to override. List of available brain parameters, with their default value and accepted range, can be retrieved from `ogo_shield_brain_parameters` data source.",
+				ElementType: brainOverrideType{},
 				Default: mapdefault.StaticValue(
 					types.MapValueMust(
-						types.Float64Type,
+						brainOverrideType{},
 						map[string]attr.Value{},
 					),
 				),
@@ -357,11 +428,25 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					Attributes: map[string]schema.Attribute{
 						"ip": schema.StringAttribute{
 							Required:    true,
-							Description: "IP address list never blocked by Ogo Shield.",
+							CustomType:  ipRangeType{},
+							Description: "IPv4 or IPv6 address or CIDR range never blocked by Ogo Shield.",
+							Validators: []validator.String{
+								ipRange(),
+							},
 						},
 						"comment": schema.StringAttribute{
 							Optional:    true,
 							Description: "Description associated with this IP list.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"expires_at": schema.StringAttribute{
+							Optional:    true,
+							Description: expiresAtDescription("IP exception"),
+							Validators: []validator.String{
+								rfc3339(),
+							},
 						},
 					},
 				},
@@ -369,18 +454,44 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					types.SetValueMust(
 						types.ObjectType{
 							AttrTypes: map[string]attr.Type{
-								"ip":      types.StringType,
-								"comment": types.StringType,
+								"ip":         ipRangeType{},
+								"comment":    types.StringType,
+								"expires_at": types.StringType,
 							},
 						},
 						[]attr.Value{},
 					),
 				),
 			},
+			"ip_exceptions_source": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "Local file listing IPs in passthrough mode, in addition to `ip_exceptions`. " +
+					"The file holds one IP address, CIDR range or address range (first-last) per line, blank lines and comments starting with # are ignored. " +
+					"Ranges are aggregated into the smallest list of CIDR ranges on apply, and site is updated when file content changes. " +
+					"File entries are not listed in `ip_exceptions`.",
+				Attributes: map[string]schema.Attribute{
+					"file": schema.StringAttribute{
+						Required:    true,
+						Description: "Path of the IP list file.",
+					},
+					"comment": schema.StringAttribute{
+						Optional:    true,
+						Description: "Description associated with the IP exceptions of the file.",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"content_sha256": schema.StringAttribute{
+						Computed:    true,
+						Description: "SHA-256 hash of the file content applied to the site.",
+					},
+				},
+			},
 			"rewrite_rules": schema.ListNestedAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "Rewrite a path of your website. Rewrite rules are parsed in order of declaration.",
+				Optional: true,
+				Computed: true,
+				Description: "Rewrite a path of your website. Rewrite rules are parsed in order of `priority`, then of declaration. " +
+					"Plan warns about invalid regular expressions, paths rewritten again by later rules and looping rewrite rules.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"active": schema.BoolAttribute{
@@ -389,9 +500,18 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							Description: "Flag to enable (**true**) or disable (**false**) rewrite rule. (default: **true**).",
 							Default:     booldefault.StaticBool(true),
 						},
+						"priority": schema.Int64Attribute{
+							Optional: true,
+							Description: "Order of application of this rewrite rule, lower priorities are applied first. " +
+								"Rewrite rules without priority are applied after, in order of declaration. " +
+								"Rewrite rules can then be added anywhere in the list without changing the following ones in plans.",
+						},
 						"comment": schema.StringAttribute{
 							Optional:    true,
 							Description: "Description associated with this rewrite rule.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
 						"rewrite_source": schema.StringAttribute{
 							Required:    true,
@@ -408,6 +528,7 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						types.ObjectType{
 							AttrTypes: map[string]attr.Type{
 								"active":              types.BoolType,
+								"priority":            types.Int64Type,
 								"comment":             types.StringType,
 								"rewrite_source":      types.StringType,
 								"rewrite_destination": types.StringType,
@@ -418,9 +539,10 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				),
 			},
 			"rules": schema.ListNestedAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "Restrict access to given URLs. Rules are parsed in order of declaration. The engine stops at the first URL match.",
+				Optional: true,
+				Computed: true,
+				Description: "Restrict access to given URLs. Rules are parsed in order of `priority`, then of declaration. The engine stops at the first URL match. " +
+					"Plan warns about rules never applied because of earlier rules, overlapping rules with conflicting settings and duplicate rules.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"active": schema.BoolAttribute{
@@ -446,19 +568,41 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							Description: "Enable or disable caching on this rule. Option can be used only if site caching is enabled. (default: **false**).",
 							Default:     booldefault.StaticBool(false),
 						},
+						"priority": schema.Int64Attribute{
+							Optional: true,
+							Description: "Order of application of this rule, lower priorities are applied first. " +
+								"Rules without priority are applied after, in order of declaration. " +
+								"Rules can then be added anywhere in the list without changing the following ones in plans.",
+						},
 						"comment": schema.StringAttribute{
 							Optional:    true,
 							Description: "Description associated with this rule.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
 						"paths": schema.SetAttribute{
 							Required:    true,
-							Description: "List of URL paths for which the rule is applied.",
+							Description: "List of URL paths for which the rule is applied, as RE2 regular expressions matched from the start of request paths (e.g. `/admin` matches `/admin/login`).",
 							ElementType: types.StringType,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(pathRegexp()),
+							},
 						},
 						"whitelisted_ips": schema.SetAttribute{
 							Required:    true,
-							Description: "Authorized IP addresses list.",
-							ElementType: types.StringType,
+							Description: "Authorized IPv4 or IPv6 addresses or CIDR ranges list.",
+							ElementType: ipRangeType{},
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(ipRange()),
+							},
+						},
+						"expires_at": schema.StringAttribute{
+							Optional:    true,
+							Description: expiresAtDescription("rule"),
+							Validators: []validator.String{
+								rfc3339(),
+							},
 						},
 					},
 				},
@@ -469,9 +613,11 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 								"active":          types.BoolType,
 								"action":          types.StringType,
 								"cache":           types.BoolType,
+								"priority":        types.Int64Type,
 								"comment":         types.StringType,
 								"paths":           types.SetType{ElemType: types.StringType},
-								"whitelisted_ips": types.SetType{ElemType: types.StringType},
+								"whitelisted_ips": types.SetType{ElemType: ipRangeType{}},
+								"expires_at":      types.StringType,
 							},
 						},
 						[]attr.Value{},
@@ -486,11 +632,24 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					Attributes: map[string]schema.Attribute{
 						"path": schema.StringAttribute{
 							Required:    true,
-							Description: "Path of the URL never blocked by Ogo Shield.",
+							Description: "Path of the URL never blocked by Ogo Shield, as a regular expression.",
+							Validators: []validator.String{
+								pathRegexp(),
+							},
 						},
 						"comment": schema.StringAttribute{
 							Optional:    true,
 							Description: "Description associated with this URL exception.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"expires_at": schema.StringAttribute{
+							Optional:    true,
+							Description: expiresAtDescription("URL exception"),
+							Validators: []validator.String{
+								rfc3339(),
+							},
 						},
 					},
 				},
@@ -498,8 +657,9 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					types.SetValueMust(
 						types.ObjectType{
 							AttrTypes: map[string]attr.Type{
-								"path":    types.StringType,
-								"comment": types.StringType,
+								"path":       types.StringType,
+								"comment":    types.StringType,
+								"expires_at": types.StringType,
 							},
 						},
 						[]attr.Value{},
@@ -518,11 +678,113 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					),
 				),
 			},
+			"manage_exceptions_and_rules": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Manage `ip_exceptions`, `url_exceptions`, `rules` and `rewrite_rules` with this resource (default: **true**). " +
+					"Set to **false** to manage them with `ogo_shield_site_ip_exception`, `ogo_shield_site_url_exception`, " +
+					"`ogo_shield_site_rule` and `ogo_shield_site_rewrite_rule` resources instead, existing entries are then left untouched whatever the `*_management` attributes.",
+				Default: booldefault.StaticBool(true),
+			},
+			"ip_exceptions_management": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: siteManagementDescription("ip_exceptions", "IP exceptions"),
+				Default:     stringdefault.StaticString(managementAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managementAuthoritative, managementAdditive),
+				},
+			},
+			"url_exceptions_management": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: siteManagementDescription("url_exceptions", "URL exceptions"),
+				Default:     stringdefault.StaticString(managementAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managementAuthoritative, managementAdditive),
+				},
+			},
+			"rewrite_rules_management": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: siteManagementDescription("rewrite_rules", "rewrite rules"),
+				Default:     stringdefault.StaticString(managementAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managementAuthoritative, managementAdditive),
+				},
+			},
+			"rules_management": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: siteManagementDescription("rules", "rules"),
+				Default:     stringdefault.StaticString(managementAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(managementAuthoritative, managementAdditive),
+				},
+			},
+			"wait_for": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "Wait for the site to reach expected state after creation or update. " +
+					"Site is polled until all conditions are met or timeout expires. " +
+					"Timeout after creation is reported as a warning, so that the created site is kept in state and not replaced on next apply, " +
+					"timeout after update is an error.",
+				Attributes: map[string]schema.Attribute{
+					"status": schema.SetAttribute{
+						Optional:    true,
+						Description: "Wait for site `status` to be one of these values, e.g. `[\"LE_CERT\", \"CUST_CERT\"]`.",
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.OneOf("CREATED", "DNS_ERROR", "ONLINE", "LE_CERT", "CUST_CERT", "LE_EXP", "CUST_EXP", "OFFLINE"),
+							),
+						},
+					},
+					"cdn_status": schema.SetAttribute{
+						Optional:    true,
+						Description: "Wait for site `cdn_status` to be one of these values, e.g. `[\"ACTIVE\"]`. Requires `cdn` to be set.",
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.OneOf("ACTIVE", "ACTIVATION_IN_PROGRESS", "SYNC_IN_PROGRESS"),
+							),
+						},
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Prevent the site from being deleted, replaced or migrated to another cluster or contract by Terraform (default: **false**). " +
+					"Must be set to **false** and applied before the site can be destroyed or migrated.",
+				Default: booldefault.StaticBool(false),
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Action on resource destruction (default: **delete**). Supported values:\n" +
+					"  * **delete**: Site is deleted from Ogo Shield\n" +
+					"  * **abandon**: Site is only removed from Terraform state and keeps running on Ogo Shield, " +
+					"e.g. to hand it over to another workspace. Abandoning a site is allowed even if `deletion_protection` is enabled.",
+				Default: stringdefault.StaticString(siteOnDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(siteOnDestroyDelete, siteOnDestroyAbandon),
+				},
+			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Last resource updated by Terraform.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				CreateDescription: "Maximum time to wait for `wait_for` conditions after site creation, only a warning is reported on timeout (default: **30m**).",
+				UpdateDescription: "Maximum time to wait for `wait_for` conditions after site update (default: **30m**).",
+			}),
+		},
 		MarkdownDescription: "Resource `ogo_shield_site` can be used to create, " +
 			"update or delete sites configuration in Ogo Dashboard.\n\n" +
 			"This resource allow to manage all site settings.\n\n" +
@@ -531,7 +793,31 @@ func (r *siteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
-// Configure adds the provider configured client to the resource.
+// IdentitySchema defines the identity schema for the resource.
+func (r *siteResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Organization code of the site, must match provider organization if set.",
+			},
+			"domain_name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "DNS domain name of the site.",
+			},
+		},
+	}
+}
+
+// identity returns the resource identity of a site.
+func (r *siteResource) identity(domainName types.String) SiteResourceIdentityModel {
+	return SiteResourceIdentityModel{
+		Organization: types.StringValue(r.client.Organization),
+		DomainName:   domainName,
+	}
+}
+
+// Configure adds the provider configured client and settings to the resource.
 func (r *siteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
 	// sets that data after it calls the ConfigureProvider RPC.
@@ -539,18 +825,19 @@ func (r *siteResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*ogosecurity.Client)
+	data, ok := req.ProviderData.(*ogoProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected resource configure type",
-			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.strictValidation = data.StrictValidation
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -656,18 +943,27 @@ func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		s.BrainOverrides[brainParam] = val
 	}
 
-	// IP Exceptions
+	// IP Exceptions, expired entries are not sent anymore
+	now := time.Now()
 	s.IpExceptions = []ogosecurity.IpException{}
-	for _, wlip := range plan.IpExceptions {
+	for _, wlip := range withoutExpired(plan.IpExceptions, ipExceptionModelExpiresAt, now) {
 		s.IpExceptions = append(s.IpExceptions, ogosecurity.IpException{
 			Ip:      wlip.Ip.ValueString(),
 			Comment: wlip.Comment.ValueString(),
 		})
 	}
 
+	// IP Exceptions from source file
+	var sourceIpExceptions []string
+	s.IpExceptions, sourceIpExceptions, diags = plan.appendSourceIpExceptions(s.IpExceptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Rewrite Rules
 	s.RewriteRules = []ogosecurity.RewriteRule{}
-	for _, rewrite := range plan.RewriteRules {
+	for _, rewrite := range sortByPriority(plan.RewriteRules, rewriteRuleModelPriority) {
 		s.RewriteRules = append(s.RewriteRules, ogosecurity.RewriteRule{
 			Active:             rewrite.Active.ValueBool(),
 			Comment:            rewrite.Comment.ValueString(),
@@ -678,7 +974,7 @@ func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Rules access
 	s.Rules = []ogosecurity.Rule{}
-	for _, rule := range plan.Rules {
+	for _, rule := range sortByPriority(withoutExpired(plan.Rules, ruleModelExpiresAt, now), ruleModelPriority) {
 		r := ogosecurity.Rule{
 			Active:         rule.Active.ValueBool(),
 			Action:         rule.Action.ValueString(),
@@ -701,7 +997,7 @@ func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// URL Exceptions
 	s.UrlExceptions = []ogosecurity.UrlException{}
-	for _, url := range plan.UrlExceptions {
+	for _, url := range withoutExpired(plan.UrlExceptions, urlExceptionModelExpiresAt, now) {
 		s.UrlExceptions = append(s.UrlExceptions, ogosecurity.UrlException{
 			Path:    url.Path.ValueString(),
 			Comment: url.Comment.ValueString(),
@@ -723,6 +1019,18 @@ func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Track entries owned by Terraform in additive collections
+	owned := plan.siteOwnedEntriesFrom(s)
+	owned.IpExceptionsSource = sourceIpExceptions
+	resp.Diagnostics.Append(setSiteOwnedEntries(ctx, resp.Private, owned)...)
+
+	// Wait for site readiness, timeout is only a warning so that site is not
+	// tainted
+	createTimeout, diags := plan.Timeouts.Create(ctx, siteDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	site, diags = r.waitForSite(ctx, plan.WaitFor, createTimeout, site, true)
+	resp.Diagnostics.Append(diags...)
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ClusterEntrypoint4 = types.StringValue(site.Cluster.Entrypoint4)
 	plan.ClusterEntrypoint6 = types.StringValue(site.Cluster.Entrypoint6)
@@ -736,11 +1044,15 @@ func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		plan.CdnStatus = types.StringPointerValue(site.CdnStatus)
 	}
 	plan.Status = types.StringValue(site.Status)
+	plan.readContract(site)
+	plan.DnsRecords, diags = siteDnsRecordsValue(ctx, site)
+	resp.Diagnostics.Append(diags...)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(plan.DomainName))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -766,100 +1078,203 @@ func (r *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// Exceptions and rules owned by Terraform in additive collections
+	owned, diags := getSiteOwnedEntries(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Overwrite properties with refreshed state
-	state.ClusterUid = types.StringValue(site.Cluster.Uid)
-	state.ClusterEntrypoint4 = types.StringValue(site.Cluster.Entrypoint4)
-	state.ClusterEntrypoint6 = types.StringValue(site.Cluster.Entrypoint6)
-	state.ClusterEntrypointCdn = types.StringValue(site.Cluster.EntrypointCdn)
-	state.OriginServer = types.StringValue(site.OriginServer)
-	state.OriginScheme = types.StringValue(site.OriginScheme)
-	state.OriginMtlsEnabled = types.BoolValue(site.OriginMtlsEnabled)
-	state.OriginPort = types.Int32PointerValue(site.OriginPort)
-	state.OriginSkipCertVerify = types.BoolValue(site.OriginSkipCertVerify)
-	state.RemoveXForwarded = types.BoolValue(site.RemoveXForwarded)
-	state.ForceHttps = types.BoolValue(site.ForceHttps)
-	state.AuditMode = types.BoolValue(site.AuditMode)
-	state.PassthroughMode = types.BoolValue(site.PassthroughMode)
-	state.Hsts = types.StringValue(site.Hsts)
-	state.LogExportEnabled = types.BoolValue(site.LogExportEnabled)
-	state.CacheEnabled = types.BoolValue(site.CacheEnabled)
-	state.Status = types.StringValue(site.Status)
-	state.LogExportEnabled = types.BoolValue(site.LogExportEnabled)
-	state.PassTlsClientCert = types.StringValue(site.PassTlsClientCert)
+	resp.Diagnostics.Append(state.read(ctx, site, owned)...)
+	resp.Diagnostics.Append(siteDnsWarning(site)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(state.DomainName))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// read overwrites properties with site values, unset settings are read
+// with their default value. Exceptions and rules are only read if managed
+// by the site resource, additive collections are filtered to owned entries.
+func (m *SiteResourceModel) read(ctx context.Context, site *ogosecurity.Site, owned siteOwnedEntries) diag.Diagnostics {
+	var allDiags diag.Diagnostics
+	var diags diag.Diagnostics
+
+	m.ClusterUid = types.StringValue(site.Cluster.Uid)
+	m.ClusterEntrypoint4 = types.StringValue(site.Cluster.Entrypoint4)
+	m.ClusterEntrypoint6 = types.StringValue(site.Cluster.Entrypoint6)
+	m.ClusterEntrypointCdn = types.StringValue(site.Cluster.EntrypointCdn)
+	m.OriginServer = types.StringValue(site.OriginServer)
+	m.OriginScheme = types.StringValue(site.OriginScheme)
+	m.OriginMtlsEnabled = types.BoolValue(site.OriginMtlsEnabled)
+	if !m.OriginPort.IsNull() || site.OriginPort == nil || *site.OriginPort != ogosecurity.OriginDefaultPort(site.OriginScheme) {
+		m.OriginPort = types.Int32PointerValue(site.OriginPort)
+	}
+	m.OriginSkipCertVerify = types.BoolValue(site.OriginSkipCertVerify)
+	m.RemoveXForwarded = types.BoolValue(site.RemoveXForwarded)
+	m.ForceHttps = types.BoolValue(site.ForceHttps)
+	m.AuditMode = types.BoolValue(site.AuditMode)
+	m.PassthroughMode = types.BoolValue(site.PassthroughMode)
+	m.Hsts = types.StringValue(site.Hsts)
+	m.LogExportEnabled = types.BoolValue(site.LogExportEnabled)
+	m.CacheEnabled = types.BoolValue(site.CacheEnabled)
+	m.Status = types.StringValue(site.Status)
+	m.LogExportEnabled = types.BoolValue(site.LogExportEnabled)
+	m.DnsRecords, diags = siteDnsRecordsValue(ctx, site)
+	allDiags.Append(diags...)
+	m.PassTlsClientCert = types.StringValue(site.PassTlsClientCert)
 
 	// Activate certificate
-	if state.ActiveCustomerCertificate != nil {
-		if state.ActiveCustomerCertificate.Hash != types.StringValue(site.ActiveCustomerCertificate.Hash) {
-			if state.ActiveCustomerCertificate.P12File.ValueString() != "" {
-				state.ActiveCustomerCertificate.P12File = types.StringValue("")
-			} else if state.ActiveCustomerCertificate.P12Content64.ValueString() != "" {
-				state.ActiveCustomerCertificate.P12Content64 = types.StringValue("")
+	if m.ActiveCustomerCertificate != nil {
+		hash := ""
+		if site.ActiveCustomerCertificate != nil {
+			hash = site.ActiveCustomerCertificate.Hash
+		}
+		if m.ActiveCustomerCertificate.Hash != types.StringValue(hash) {
+			if m.ActiveCustomerCertificate.P12File.ValueString() != "" {
+				m.ActiveCustomerCertificate.P12File = types.StringValue("")
+			} else if m.ActiveCustomerCertificate.P12Content64.ValueString() != "" {
+				m.ActiveCustomerCertificate.P12Content64 = types.StringValue("")
 			}
 		}
 	}
 
 	// CDN
-	if site.Cdn != nil {
-		state.Cdn = types.StringPointerValue(site.Cdn)
-		state.CdnStatus = types.StringPointerValue(site.CdnStatus)
-		state.ClusterEntrypointCdn = types.StringValue(site.Cluster.EntrypointCdn)
+	if site.Cdn != nil && *site.Cdn != "" {
+		m.Cdn = types.StringPointerValue(site.Cdn)
+		m.CdnStatus = types.StringPointerValue(site.CdnStatus)
+		m.ClusterEntrypointCdn = types.StringValue(site.Cluster.EntrypointCdn)
+	} else {
+		m.Cdn = types.StringNull()
+		m.CdnStatus = types.StringNull()
 	}
 
 	// Contract
-	if site.Contract != nil {
-		if site.Contract.Number != "" {
-			state.ContractNumber = types.StringValue(site.Contract.Number)
-		}
-	}
+	m.readContract(site)
 
 	// TLS Options
-	if site.TlsOptions != nil {
-		state.TlsOptionsUid = types.StringValue(site.TlsOptions.Uid)
+	if site.TlsOptions != nil && site.TlsOptions.Uid != "" {
+		m.TlsOptionsUid = types.StringValue(site.TlsOptions.Uid)
+	} else {
+		m.TlsOptionsUid = types.StringNull()
 	}
 
 	// Blacklist Countries
-	state.BlacklistedCountries = []types.String{}
+	m.BlacklistedCountries = []types.String{}
 	for _, country := range site.BlacklistedCountries {
-		state.BlacklistedCountries = append(state.BlacklistedCountries, types.StringValue(country))
+		m.BlacklistedCountries = append(m.BlacklistedCountries, types.StringValue(country))
 	}
 
 	// Brain parameters overrides
-	state.BrainOverrides, diags = types.MapValueFrom(ctx, types.Float64Type, site.BrainOverrides)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	brainOverrides := site.BrainOverrides
+	if brainOverrides == nil {
+		brainOverrides = map[string]float64{}
+	}
+	m.BrainOverrides, diags = types.MapValueFrom(ctx, brainOverrideType{}, brainOverrides)
+	allDiags.Append(diags...)
+	if allDiags.HasError() {
+		return allDiags
+	}
+
+	// Exceptions and rules are left untouched if managed by dedicated resources
+	if m.ManageExceptionsAndRules.IsNull() {
+		m.ManageExceptionsAndRules = types.BoolValue(true)
+	}
+	for _, mode := range []*types.String{
+		&m.IpExceptionsManagement,
+		&m.UrlExceptionsManagement,
+		&m.RewriteRulesManagement,
+		&m.RulesManagement,
+	} {
+		if mode.IsNull() {
+			*mode = types.StringValue(managementAuthoritative)
+		}
+	}
+	if m.DeletionProtection.IsNull() {
+		m.DeletionProtection = types.BoolValue(false)
+	}
+	if m.OnDestroy.IsNull() {
+		m.OnDestroy = types.StringValue(siteOnDestroyDelete)
+	}
+	if m.ManageExceptionsAndRules.ValueBool() {
+		// Only keep entries owned by Terraform in additive collections
+		m.filterOwnedEntries(site, owned)
+		m.filterSourceIpExceptions(site, owned)
+		allDiags.Append(m.readExceptionsAndRules(site, time.Now())...)
 	}
 
+	// Tags
+	m.Tags = []types.String{}
+	for _, tag := range site.Tags {
+		m.Tags = append(m.Tags, types.StringValue(tag))
+	}
+
+	return allDiags
+}
+
+// readContract overwrites contract number with site value, contract number
+// is kept if site contract is unknown.
+func (m *SiteResourceModel) readContract(site *ogosecurity.Site) {
+	if site.Contract != nil && site.Contract.Number != "" {
+		m.ContractNumber = types.StringValue(site.Contract.Number)
+	} else if m.ContractNumber.IsUnknown() {
+		m.ContractNumber = types.StringNull()
+	}
+}
+
+// readExceptionsAndRules overwrites exceptions and rules with site values,
+// empty comments are read as null. Expiry dates are kept from prior values,
+// and warnings are returned for expired entries still present on site.
+func (m *SiteResourceModel) readExceptionsAndRules(site *ogosecurity.Site, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var expiredIpExceptions []IpExceptionModel
+	var expiredUrlExceptions []UrlExceptionModel
+	var expiredRules []RuleModel
+	domainName := m.DomainName.ValueString()
+	priorIpExceptions, priorUrlExceptions := m.IpExceptions, m.UrlExceptions
+	priorRewriteRules, priorRules := m.RewriteRules, m.Rules
+
 	// IP Exceptions
-	state.IpExceptions = []IpExceptionModel{}
+	m.IpExceptions = []IpExceptionModel{}
 	for _, wlip := range site.IpExceptions {
-		state.IpExceptions = append(state.IpExceptions, IpExceptionModel{
-			Ip:      types.StringValue(wlip.Ip),
-			Comment: types.StringValue(wlip.Comment),
+		m.IpExceptions = append(m.IpExceptions, IpExceptionModel{
+			Ip:      ipRangeStringValue(wlip.Ip),
+			Comment: stringValueOrNull(wlip.Comment),
 		})
 	}
+	m.IpExceptions, expiredIpExceptions = restoreExpiry(m.IpExceptions, priorIpExceptions, ipExceptionModelKey, ipExceptionModelExpiresAt, setIpExceptionModelExpiresAt, now)
+	diags.Append(expiredEntriesDiagnostics(domainName, "ip_exceptions", expiredIpExceptions, ipExceptionModelKey)...)
 
 	// Rewrite rules
-	state.RewriteRules = []RewriteRuleModel{}
+	m.RewriteRules = []RewriteRuleModel{}
 	for _, rewrite := range site.RewriteRules {
-		state.RewriteRules = append(state.RewriteRules, RewriteRuleModel{
+		m.RewriteRules = append(m.RewriteRules, RewriteRuleModel{
 			Active:             types.BoolValue(rewrite.Active),
-			Comment:            types.StringValue(rewrite.Comment),
+			Comment:            stringValueOrNull(rewrite.Comment),
 			RewriteSource:      types.StringValue(rewrite.RewriteSource),
 			RewriteDestination: types.StringValue(rewrite.RewriteDestination),
 		})
 	}
+	m.RewriteRules = restorePriorities(m.RewriteRules, priorRewriteRules, rewriteRuleModelKey, rewriteRuleModelPriority, setRewriteRuleModelPriority)
 
 	// Rules access
-	state.Rules = []RuleModel{}
+	m.Rules = []RuleModel{}
 	for _, rule := range site.Rules {
 		r := RuleModel{
 			Active:         types.BoolValue(rule.Active),
 			Action:         types.StringValue(rule.Action),
 			Cache:          types.BoolValue(rule.Cache),
-			Comment:        types.StringValue(rule.Comment),
+			Comment:        stringValueOrNull(rule.Comment),
 			Paths:          []types.String{},
-			WhitelistedIps: []types.String{},
+			WhitelistedIps: []ipRangeValue{},
 		}
 
 		for _, path := range rule.Paths {
@@ -867,33 +1282,27 @@ func (r *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 
 		for _, ip := range rule.WhitelistedIps {
-			r.WhitelistedIps = append(r.WhitelistedIps, types.StringValue(ip))
+			r.WhitelistedIps = append(r.WhitelistedIps, ipRangeStringValue(ip))
 		}
 
-		state.Rules = append(state.Rules, r)
+		m.Rules = append(m.Rules, r)
 	}
+	m.Rules = restorePriorities(m.Rules, priorRules, ruleModelKey, ruleModelPriority, setRuleModelPriority)
+	m.Rules, expiredRules = restoreExpiry(m.Rules, priorRules, ruleModelKey, ruleModelExpiresAt, setRuleModelExpiresAt, now)
+	diags.Append(expiredEntriesDiagnostics(domainName, "rules", expiredRules, ruleModelKey)...)
 
 	// URL Exceptions
-	state.UrlExceptions = []UrlExceptionModel{}
+	m.UrlExceptions = []UrlExceptionModel{}
 	for _, url := range site.UrlExceptions {
-		state.UrlExceptions = append(state.UrlExceptions, UrlExceptionModel{
+		m.UrlExceptions = append(m.UrlExceptions, UrlExceptionModel{
 			Path:    types.StringValue(url.Path),
-			Comment: types.StringValue(url.Comment),
+			Comment: stringValueOrNull(url.Comment),
 		})
 	}
+	m.UrlExceptions, expiredUrlExceptions = restoreExpiry(m.UrlExceptions, priorUrlExceptions, urlExceptionModelKey, urlExceptionModelExpiresAt, setUrlExceptionModelExpiresAt, now)
+	diags.Append(expiredEntriesDiagnostics(domainName, "url_exceptions", expiredUrlExceptions, urlExceptionModelKey)...)
 
-	// Tags
-	state.Tags = []types.String{}
-	for _, tag := range site.Tags {
-		state.Tags = append(state.Tags, types.StringValue(tag))
-	}
-
-	// Set refreshed state
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	return diags
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -906,6 +1315,14 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Retrieve values from state
+	var state SiteResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Create new site
 	var tlsOpt *ogosecurity.TlsOptions
 	s := ogosecurity.Site{
@@ -998,18 +1415,27 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		s.BrainOverrides[brainParam] = val
 	}
 
-	// IP Exceptions
+	// IP Exceptions, expired entries are not sent anymore
+	now := time.Now()
 	s.IpExceptions = []ogosecurity.IpException{}
-	for _, wlip := range plan.IpExceptions {
+	for _, wlip := range withoutExpired(plan.IpExceptions, ipExceptionModelExpiresAt, now) {
 		s.IpExceptions = append(s.IpExceptions, ogosecurity.IpException{
 			Ip:      wlip.Ip.ValueString(),
 			Comment: wlip.Comment.ValueString(),
 		})
 	}
 
+	// IP Exceptions from source file
+	var sourceIpExceptions []string
+	s.IpExceptions, sourceIpExceptions, diags = plan.appendSourceIpExceptions(s.IpExceptions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Rewrite Rules
 	s.RewriteRules = []ogosecurity.RewriteRule{}
-	for _, rewrite := range plan.RewriteRules {
+	for _, rewrite := range sortByPriority(plan.RewriteRules, rewriteRuleModelPriority) {
 		s.RewriteRules = append(s.RewriteRules, ogosecurity.RewriteRule{
 			Active:             rewrite.Active.ValueBool(),
 			Comment:            rewrite.Comment.ValueString(),
@@ -1020,7 +1446,7 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Rules access
 	s.Rules = []ogosecurity.Rule{}
-	for _, rule := range plan.Rules {
+	for _, rule := range sortByPriority(withoutExpired(plan.Rules, ruleModelExpiresAt, now), ruleModelPriority) {
 		r := ogosecurity.Rule{
 			Active:         rule.Active.ValueBool(),
 			Action:         rule.Action.ValueString(),
@@ -1043,7 +1469,7 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// URL Exceptions
 	s.UrlExceptions = []ogosecurity.UrlException{}
-	for _, url := range plan.UrlExceptions {
+	for _, url := range withoutExpired(plan.UrlExceptions, urlExceptionModelExpiresAt, now) {
 		s.UrlExceptions = append(s.UrlExceptions, ogosecurity.UrlException{
 			Path:    url.Path.ValueString(),
 			Comment: url.Comment.ValueString(),
@@ -1056,15 +1482,88 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		s.Tags = append(s.Tags, tag.ValueString())
 	}
 
-	site, err := r.client.UpdateSite(s)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating site",
-			"Could not update site, unexpected error: "+err.Error(),
-		)
-		return
+	// Keep exceptions and rules not managed by this resource
+	unlock := lockSite(s.DomainName)
+	defer unlock()
+	newOwned := plan.siteOwnedEntriesFrom(s)
+	newOwned.IpExceptionsSource = sourceIpExceptions
+	migrate := siteMigrationRequired(plan, state)
+	var current *ogosecurity.Site
+	if migrate || !plan.ManageExceptionsAndRules.ValueBool() || plan.hasAdditiveCollection() {
+		var err error
+		current, err = r.client.GetSite(s.DomainName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Ogo site",
+				"Could not read Ogo site domain name "+s.DomainName+": "+err.Error(),
+			)
+			return
+		}
+
+		if !plan.ManageExceptionsAndRules.ValueBool() {
+			// Exceptions and rules managed by dedicated resources
+			s.IpExceptions = current.IpExceptions
+			s.UrlExceptions = current.UrlExceptions
+			s.RewriteRules = current.RewriteRules
+			s.Rules = current.Rules
+			newOwned = siteOwnedEntries{}
+		} else {
+			// Merge additive collections with entries not owned by Terraform
+			owned, diags := getSiteOwnedEntries(ctx, req.Private)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			plan.mergeOwnedEntries(&s, *current, owned)
+		}
 	}
 
+	var site *ogosecurity.Site
+	if migrate {
+		// Never delete a protected site, even if plan was not checked
+		for _, d := range siteMigrationDiagnostics(plan, state).Errors() {
+			resp.Diagnostics.Append(d)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Move site to target cluster and contract
+		if plan.ContractNumber.ValueString() != "" {
+			s.Contract = &ogosecurity.Contract{
+				Number: plan.ContractNumber.ValueString(),
+			}
+		}
+
+		site, diags = r.migrateSite(current, s)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		var err error
+		site, err = r.client.UpdateSite(s)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating site",
+				"Could not update site, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	unlock()
+
+	// Track entries owned by Terraform in additive collections
+	resp.Diagnostics.Append(setSiteOwnedEntries(ctx, resp.Private, newOwned)...)
+
+	// Wait for site readiness
+	updateTimeout, diags := plan.Timeouts.Update(ctx, siteDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	site, diags = r.waitForSite(ctx, plan.WaitFor, updateTimeout, site, false)
+	resp.Diagnostics.Append(diags...)
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ClusterEntrypoint4 = types.StringValue(site.Cluster.Entrypoint4)
 	plan.ClusterEntrypoint6 = types.StringValue(site.Cluster.Entrypoint6)
@@ -1078,14 +1577,143 @@ func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		plan.CdnStatus = types.StringPointerValue(site.CdnStatus)
 	}
 	plan.Status = types.StringValue(site.Status)
+	plan.readContract(site)
+	plan.DnsRecords, diags = siteDnsRecordsValue(ctx, site)
+	resp.Diagnostics.Append(diags...)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(plan.DomainName))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ValidateConfig validates attributes consistency.
+func (r *siteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var manage types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("manage_exceptions_and_rules"), &manage)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// CDN status can't be awaited without CDN
+	var cdn types.String
+	var waitForCdnStatus types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cdn"), &cdn)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wait_for").AtName("cdn_status"), &waitForCdnStatus)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if cdn.IsNull() && !waitForCdnStatus.IsNull() && !waitForCdnStatus.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for").AtName("cdn_status"),
+			"Conflicting site attributes",
+			"Attribute wait_for.cdn_status can't be set when cdn is not set.",
+		)
+	}
+
+	// Exceptions and rules can't be set if managed by dedicated resources
+	if manage.IsNull() || manage.IsUnknown() || manage.ValueBool() {
+		return
+	}
+
+	for _, name := range []string{"ip_exceptions", "ip_exceptions_source", "url_exceptions", "rules", "rewrite_rules"} {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value != nil && !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Conflicting site attributes",
+				"Attribute "+name+" can't be set when manage_exceptions_and_rules is false.",
+			)
+		}
+	}
+}
+
+// ModifyPlan validates planned values which can only be checked with Ogo API,
+// and analyses rules and rewrite rules.
+func (r *siteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Site destruction or replacement
+	if !req.State.Raw.IsNull() && (req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0) {
+		resp.Diagnostics.Append(checkSiteDeletionProtection(ctx, req.State)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Nothing to validate on resource destruction
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Site migration to another cluster or contract deletes the site
+	if !req.State.Raw.IsNull() {
+		var plan, state SiteResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(siteMigrationDiagnostics(plan, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Shadowed and overlapping rules, looping and chained rewrite rules
+	var rules, rewriteRules attr.Value
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rewrite_rules"), &rewriteRules)...)
+	resp.Diagnostics.Append(siteRulesDiagnostics(ctx, rules, r.strictValidation)...)
+	resp.Diagnostics.Append(siteRewriteRulesDiagnostics(ctx, rewriteRules, r.strictValidation)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// IP exceptions source file content
+	planIpExceptionsSource(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		return
+	}
+
+	// Cluster migration target entrypoints and DNS records
+	if !req.State.Raw.IsNull() {
+		r.planSiteMigration(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Brain parameters overrides
+	var planOverrides, stateOverrides types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("brain_overrides"), &planOverrides)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("brain_overrides"), &stateOverrides)...)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if len(planOverrides.Elements()) > 0 && !planOverrides.Equal(stateOverrides) {
+		catalog, err := r.client.GetAllBrainParameters()
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("brain_overrides"),
+				"Unable to validate brain parameters",
+				"Could not retrieve brain parameters from Ogo, overrides will only be checked on apply: "+err.Error(),
+			)
+		} else {
+			resp.Diagnostics.Append(validateBrainOverrides(planOverrides, catalog)...)
+		}
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -1098,6 +1726,20 @@ func (r *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(checkSiteDeletionProtection(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only remove abandoned site from state
+	if state.OnDestroy.ValueString() == siteOnDestroyAbandon {
+		resp.Diagnostics.AddWarning(
+			"Site abandoned",
+			"Site "+state.DomainName.ValueString()+" has been removed from Terraform state but has not been deleted from Ogo Shield.",
+		)
+		return
+	}
+
 	// Delete existing site
 	err := r.client.DeleteSite(state.DomainName.ValueString())
 	if err != nil {
@@ -1109,7 +1751,57 @@ func (r *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// checkSiteDeletionProtection returns an error if site from state can't be
+// deleted.
+func checkSiteDeletionProtection(ctx context.Context, state tfsdk.State) diag.Diagnostics {
+	var domainName, onDestroy types.String
+	var deletionProtection types.Bool
+
+	diags := state.GetAttribute(ctx, path.Root("domain_name"), &domainName)
+	diags.Append(state.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if deletionProtection.ValueBool() && onDestroy.ValueString() != siteOnDestroyAbandon {
+		diags.AddError(
+			"Site deletion protection enabled",
+			"Site "+domainName.ValueString()+" can't be deleted or replaced while deletion_protection is enabled. "+
+				"Set deletion_protection to false and apply before destroying it, or set on_destroy to abandon to only remove it from Terraform state.",
+		)
+	}
+
+	return diags
+}
+
 func (r *siteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import domain name and save to name attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+	var domainName string
+	if req.ID != "" {
+		// Import ID format is [<organization>/]<domain_name>
+		id, diags := parseOrganizationImportID(r.client, req.ID, "domain_name")
+		resp.Diagnostics.Append(diags...)
+		domainName = id
+	} else {
+		// Import identity
+		var identity SiteResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(checkImportOrganization(r.client, identity.Organization.ValueString())...)
+		domainName = identity.DomainName.ValueString()
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(types.StringValue(domainName)))...)
+}
+
+// stringValueOrNull returns a null value for empty strings.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
 }
@@ -4,10 +4,19 @@
 package provider
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccSiteResource(t *testing.T) {
@@ -55,6 +64,12 @@ resource "ogo_shield_site" "foo" {
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "cluster_entrypoint_cdn", "cl-gla36e56b1.maps.cdn.orange.com"),
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "cluster_uid", clusterUid),
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "domain_name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.#", "2"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.0.name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.0.type", "A"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.0.value", clusterEntrypoint4),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.1.type", "AAAA"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "dns_records.1.value", clusterEntrypoint6),
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "force_https", "false"),
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "hsts", "hsts"),
 					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "0"),
@@ -376,3 +391,602 @@ resource "ogo_shield_site" "bar" {
 		},
 	})
 }
+
+func TestAccSiteResourceInvalidCountry(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name           = "foo.example.com"
+  cluster_uid           = "cluster"
+  origin_server         = "172.18.1.12"
+  blacklisted_countries = ["CN", "UK"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "GB"`),
+			},
+		},
+	})
+}
+
+func TestAccSiteResourceInvalidExceptionsAndRules(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "cluster"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    { ip = "192.0.2.0/33" },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid IP address or CIDR range`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "cluster"
+  origin_server = "172.18.1.12"
+  rules = [
+    {
+      paths           = ["/api"]
+      whitelisted_ips = ["10.10.9"]
+    },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid IP address or CIDR range`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name    = "foo.example.com"
+  cluster_uid    = "cluster"
+  origin_server  = "172.18.1.12"
+  url_exceptions = [
+    { path = "^/(?!admin)" },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid path regular expression`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name    = "foo.example.com"
+  cluster_uid    = "cluster"
+  origin_server  = "172.18.1.12"
+  url_exceptions = [
+    { path = "^/health", comment = "" },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`string length must be at least 1`),
+			},
+		},
+	})
+}
+
+func TestAccSiteResourceInvalidBrainOverride(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name     = "foo.example.com"
+  cluster_uid     = "` + clusterUid + `"
+  origin_server   = "172.18.1.12"
+  brain_overrides = {
+    "/ACTOR/DRIVE_UNKNOWN_BELIEF": 0.5,
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown brain parameter`),
+			},
+		},
+	})
+}
+
+func TestAccSiteResourceAdditiveExceptions(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name              = "foo.example.com"
+  cluster_uid              = "` + clusterUid + `"
+  origin_server            = "172.18.1.12"
+  ip_exceptions_management = "additive"
+  ip_exceptions = [
+    { ip = "192.0.2.0/24", comment = "Office" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions_management", "additive"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "url_exceptions_management", "authoritative"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.0.ip", "192.0.2.0/24"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name              = "foo.example.com"
+  cluster_uid              = "` + clusterUid + `"
+  origin_server            = "172.18.1.12"
+  ip_exceptions_management = "additive"
+  ip_exceptions = [
+    { ip = "198.51.100.7", comment = "VPN" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.0.ip", "198.51.100.7"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceIpExceptionsSource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	file := filepath.Join(t.TempDir(), "partners.txt")
+	writeIpList := func(content string) string {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	firstHash := writeIpList("# Partners\n192.0.2.0/25\n192.0.2.128/25\n")
+
+	config := providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    { ip = "198.51.100.7", comment = "VPN" },
+  ]
+  ip_exceptions_source = {
+    file    = "` + file + `"
+    comment = "Partners"
+  }
+}
+`
+
+	var secondHash string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.0.ip", "198.51.100.7"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions_source.content_sha256", firstHash),
+				),
+			},
+			// Update on file content change
+			{
+				PreConfig: func() {
+					secondHash = writeIpList("# Partners\n192.0.2.0/24\n203.0.113.8-203.0.113.15\n")
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ogo_shield_site.foo", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "1"),
+					resource.TestCheckResourceAttrWith("ogo_shield_site.foo", "ip_exceptions_source.content_sha256", func(value string) error {
+						if value != secondHash {
+							return fmt.Errorf("expected content_sha256 %s, got %s", secondHash, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceRulesPriority(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  rules = [
+    { priority = 20, paths = ["/api"], whitelisted_ips = [] },
+    { priority = 30, paths = ["/"], whitelisted_ips = [] },
+  ]
+  rewrite_rules = [
+    { priority = 20, rewrite_source = "^/old", rewrite_destination = "/new" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.#", "2"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.0.priority", "20"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.1.priority", "30"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rewrite_rules.0.priority", "20"),
+				),
+			},
+			// Rules applied first are appended to the lists
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  rules = [
+    { priority = 20, paths = ["/api"], whitelisted_ips = [] },
+    { priority = 30, paths = ["/"], whitelisted_ips = [] },
+    { priority = 10, paths = ["/api/admin"], whitelisted_ips = ["10.10.9.0/24"] },
+  ]
+  rewrite_rules = [
+    { priority = 20, rewrite_source = "^/old", rewrite_destination = "/new" },
+    { priority = 10, rewrite_source = "^/older", rewrite_destination = "/old" },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.#", "3"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.0.paths.0", "/api"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.2.paths.0", "/api/admin"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.2.priority", "10"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rewrite_rules.1.rewrite_source", "^/older"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceExpiresAt(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Expired entries are not sent to Ogo, but kept in state
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    { ip = "10.10.10.10", comment = "Scanner", expires_at = "2020-01-01T00:00:00Z" },
+    { ip = "10.10.10.11", expires_at = "2999-01-01T00:00:00Z" },
+  ]
+  url_exceptions = [
+    { path = "^/scan", expires_at = "2020-01-01T00:00:00Z" },
+  ]
+  rules = [
+    { paths = ["/api"], whitelisted_ips = [], expires_at = "2020-01-01T00:00:00Z" },
+    { paths = ["/"], whitelisted_ips = [] },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "2"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "url_exceptions.#", "1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.#", "2"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.0.expires_at", "2020-01-01T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("ogo_shield_site.foo", "rules.1.expires_at"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceInvalidExpiresAt(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "abcd"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    { ip = "10.10.10.10", expires_at = "2025-12-31" },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`not an RFC 3339 date and time`),
+			},
+		},
+	})
+}
+
+func TestAccSiteResourceInvalidWaitFor(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "abcd"
+  origin_server = "172.18.1.12"
+  wait_for = {
+    cdn_status = ["ACTIVE"]
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`wait_for.cdn_status can't be set when cdn is not set`),
+			},
+		},
+	})
+}
+
+func TestAccSiteResourceDeletionProtection(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	config := func(deletionProtection string) string {
+		return providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name         = "foo.example.com"
+  cluster_uid         = "` + clusterUid + `"
+  origin_server       = "172.18.1.12"
+  deletion_protection = ` + deletionProtection + `
+}
+`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config("true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "on_destroy", "delete"),
+				),
+			},
+			// Protected site can't be destroyed
+			{
+				Config:      config("true"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Site deletion protection enabled`),
+			},
+			// Disable deletion protection before destroy
+			{
+				Config: config("false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "deletion_protection", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceImport(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	organization := os.Getenv("OGO_ORGANIZATION")
+	if organization == "" {
+		t.Errorf("OGO_ORGANIZATION must be set")
+	}
+
+	config := providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    {
+      ip = "192.0.2.0/24"
+    },
+  ]
+  rules = [
+    {
+      paths           = ["/admin"]
+      whitelisted_ips = ["10.10.9.0/24"]
+    },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("ogo_shield_site.foo", map[string]knownvalue.Check{
+						"organization": knownvalue.StringExact(organization),
+						"domain_name":  knownvalue.StringExact("foo.example.com"),
+					}),
+				},
+			},
+			// Refresh doesn't plan any change
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// Import with organization in ID
+			{
+				ResourceName:                         "ogo_shield_site.foo",
+				ImportStateId:                        organization + "/foo.example.com",
+				ImportStateVerifyIdentifierAttribute: "domain_name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Import block followed by plan is empty
+			{
+				Config:          config,
+				ResourceName:    "ogo_shield_site.foo",
+				ImportStateId:   "foo.example.com",
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportState:     true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ogo_shield_site.foo", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			// Import block with resource identity
+			{
+				Config:          config,
+				ResourceName:    "ogo_shield_site.foo",
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				ImportState:     true,
+			},
+			// Import with another organization
+			{
+				ResourceName:  "ogo_shield_site.foo",
+				ImportStateId: "another-organization/foo.example.com",
+				ImportState:   true,
+				ExpectError:   regexp.MustCompile(`doesn't match provider organization`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSiteResourceClusterMigration(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	targetClusterUid := os.Getenv("OGO_TARGET_CLUSTER_UID")
+	if targetClusterUid == "" {
+		t.Errorf("OGO_TARGET_CLUSTER_UID must be set")
+	}
+
+	config := func(clusterUid string) string {
+		return providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name   = "foo.example.com"
+  cluster_uid   = "` + clusterUid + `"
+  origin_server = "172.18.1.12"
+  ip_exceptions = [
+    { ip = "192.0.2.0/24", comment = "Office" },
+  ]
+}
+`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(clusterUid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "cluster_uid", clusterUid),
+				),
+			},
+			// Site is migrated in place
+			{
+				Config: config(targetClusterUid),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ogo_shield_site.foo", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "cluster_uid", targetClusterUid),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "ip_exceptions.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
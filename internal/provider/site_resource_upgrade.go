@@ -0,0 +1,343 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// siteResourceSchemaVersion is the current version of the site resource
+// schema. Each prior version is frozen below with its own schema and models,
+// and upgraded step by step to the current version.
+const siteResourceSchemaVersion = 1
+
+// siteResourceModelV0 maps the resource schema data of version 0.
+type siteResourceModelV0 struct {
+	DomainName                types.String                      `tfsdk:"domain_name"`
+	ClusterUid                types.String                      `tfsdk:"cluster_uid"`
+	ClusterEntrypoint4        types.String                      `tfsdk:"cluster_entrypoint_4"`
+	ClusterEntrypoint6        types.String                      `tfsdk:"cluster_entrypoint_6"`
+	ClusterEntrypointCdn      types.String                      `tfsdk:"cluster_entrypoint_cdn"`
+	ContractNumber            types.String                      `tfsdk:"contract_number"`
+	OriginServer              types.String                      `tfsdk:"origin_server"`
+	OriginScheme              types.String                      `tfsdk:"origin_scheme"`
+	OriginPort                types.Int32                       `tfsdk:"origin_port"`
+	OriginSkipCertVerify      types.Bool                        `tfsdk:"origin_skip_cert_verify"`
+	OriginMtlsEnabled         types.Bool                        `tfsdk:"origin_mtls_enabled"`
+	RemoveXForwarded          types.Bool                        `tfsdk:"remove_xforwarded"`
+	LogExportEnabled          types.Bool                        `tfsdk:"log_export_enabled"`
+	CacheEnabled              types.Bool                        `tfsdk:"cache_enabled"`
+	Status                    types.String                      `tfsdk:"status"`
+	Cdn                       types.String                      `tfsdk:"cdn"`
+	CdnStatus                 types.String                      `tfsdk:"cdn_status"`
+	ForceHttps                types.Bool                        `tfsdk:"force_https"`
+	AuditMode                 types.Bool                        `tfsdk:"audit_mode"`
+	PassthroughMode           types.Bool                        `tfsdk:"passthrough_mode"`
+	Hsts                      types.String                      `tfsdk:"hsts"`
+	PassTlsClientCert         types.String                      `tfsdk:"pass_tls_client_cert"`
+	TlsOptionsUid             types.String                      `tfsdk:"tlsoptions_uid"`
+	BrainOverrides            map[string]types.Float64          `tfsdk:"brain_overrides"`
+	ActiveCustomerCertificate *activeCustomerCertificateModelV0 `tfsdk:"active_customer_certificate"`
+	BlacklistedCountries      []types.String                    `tfsdk:"blacklisted_countries"`
+	IpExceptions              []ipExceptionModelV0              `tfsdk:"ip_exceptions"`
+	UrlExceptions             []urlExceptionModelV0             `tfsdk:"url_exceptions"`
+	RewriteRules              []rewriteRuleModelV0              `tfsdk:"rewrite_rules"`
+	Rules                     []ruleModelV0                     `tfsdk:"rules"`
+	Tags                      []types.String                    `tfsdk:"tags"`
+	LastUpdated               types.String                      `tfsdk:"last_updated"`
+}
+
+type activeCustomerCertificateModelV0 struct {
+	Cn           types.String `tfsdk:"cn"`
+	ExpiredAt    types.String `tfsdk:"expired_at"`
+	Hash         types.String `tfsdk:"hash"`
+	P12File      types.String `tfsdk:"p12_file"`
+	P12Content64 types.String `tfsdk:"p12_content64"`
+	P12Password  types.String `tfsdk:"p12_password"`
+}
+
+type rewriteRuleModelV0 struct {
+	Active             types.Bool   `tfsdk:"active"`
+	Comment            types.String `tfsdk:"comment"`
+	RewriteSource      types.String `tfsdk:"rewrite_source"`
+	RewriteDestination types.String `tfsdk:"rewrite_destination"`
+}
+
+type ruleModelV0 struct {
+	Active         types.Bool     `tfsdk:"active"`
+	Action         types.String   `tfsdk:"action"`
+	Cache          types.Bool     `tfsdk:"cache"`
+	Comment        types.String   `tfsdk:"comment"`
+	Paths          []types.String `tfsdk:"paths"`
+	WhitelistedIps []types.String `tfsdk:"whitelisted_ips"`
+}
+
+type urlExceptionModelV0 struct {
+	Path    types.String `tfsdk:"path"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+type ipExceptionModelV0 struct {
+	Ip      types.String `tfsdk:"ip"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+// siteResourceSchemaV0 returns the resource schema of version 0.
+func siteResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name":             schema.StringAttribute{Required: true},
+			"cluster_uid":             schema.StringAttribute{Required: true},
+			"cluster_entrypoint_4":    schema.StringAttribute{Computed: true},
+			"cluster_entrypoint_6":    schema.StringAttribute{Computed: true},
+			"cluster_entrypoint_cdn":  schema.StringAttribute{Computed: true},
+			"contract_number":         schema.StringAttribute{Optional: true},
+			"origin_server":           schema.StringAttribute{Required: true},
+			"origin_scheme":           schema.StringAttribute{Optional: true, Computed: true},
+			"origin_port":             schema.Int32Attribute{Optional: true},
+			"origin_skip_cert_verify": schema.BoolAttribute{Optional: true, Computed: true},
+			"origin_mtls_enabled":     schema.BoolAttribute{Optional: true, Computed: true},
+			"remove_xforwarded":       schema.BoolAttribute{Optional: true, Computed: true},
+			"log_export_enabled":      schema.BoolAttribute{Optional: true, Computed: true},
+			"cache_enabled":           schema.BoolAttribute{Optional: true, Computed: true},
+			"status":                  schema.StringAttribute{Computed: true},
+			"cdn":                     schema.StringAttribute{Optional: true},
+			"cdn_status":              schema.StringAttribute{Computed: true},
+			"force_https":             schema.BoolAttribute{Optional: true, Computed: true},
+			"audit_mode":              schema.BoolAttribute{Optional: true, Computed: true},
+			"passthrough_mode":        schema.BoolAttribute{Optional: true, Computed: true},
+			"hsts":                    schema.StringAttribute{Optional: true, Computed: true},
+			"pass_tls_client_cert":    schema.StringAttribute{Optional: true, Computed: true},
+			"tlsoptions_uid":          schema.StringAttribute{Optional: true},
+			"brain_overrides": schema.MapAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Float64Type,
+			},
+			"active_customer_certificate": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"cn":            schema.StringAttribute{Computed: true},
+					"expired_at":    schema.StringAttribute{Computed: true},
+					"hash":          schema.StringAttribute{Optional: true, Computed: true},
+					"p12_file":      schema.StringAttribute{Optional: true},
+					"p12_content64": schema.StringAttribute{Optional: true},
+					"p12_password":  schema.StringAttribute{Required: true, Sensitive: true},
+				},
+			},
+			"blacklisted_countries": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"ip_exceptions": schema.SetNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip":      schema.StringAttribute{Required: true},
+						"comment": schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+			"url_exceptions": schema.SetNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path":    schema.StringAttribute{Required: true},
+						"comment": schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+			"rewrite_rules": schema.ListNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"active":              schema.BoolAttribute{Optional: true, Computed: true},
+						"comment":             schema.StringAttribute{Optional: true},
+						"rewrite_source":      schema.StringAttribute{Required: true},
+						"rewrite_destination": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"active":  schema.BoolAttribute{Optional: true, Computed: true},
+						"action":  schema.StringAttribute{Optional: true, Computed: true},
+						"cache":   schema.BoolAttribute{Optional: true, Computed: true},
+						"comment": schema.StringAttribute{Optional: true},
+						"paths": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+						},
+						"whitelisted_ips": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"last_updated": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// UpgradeState upgrades prior versions of the resource state to the current
+// version.
+func (r *siteResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := siteResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var stateV0 siteResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &stateV0)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state, diags := stateV0.upgrade(ctx)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
+// upgrade converts version 0 state to version 1: brain overrides use
+// brainOverrideType values, and attributes added in version 1 are set to
+// their default values.
+func (m siteResourceModelV0) upgrade(ctx context.Context) (SiteResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	state := SiteResourceModel{
+		DomainName:           m.DomainName,
+		ClusterUid:           m.ClusterUid,
+		ClusterEntrypoint4:   m.ClusterEntrypoint4,
+		ClusterEntrypoint6:   m.ClusterEntrypoint6,
+		ClusterEntrypointCdn: m.ClusterEntrypointCdn,
+		ContractNumber:       m.ContractNumber,
+		OriginServer:         m.OriginServer,
+		OriginScheme:         m.OriginScheme,
+		OriginPort:           m.OriginPort,
+		OriginSkipCertVerify: m.OriginSkipCertVerify,
+		OriginMtlsEnabled:    m.OriginMtlsEnabled,
+		RemoveXForwarded:     m.RemoveXForwarded,
+		LogExportEnabled:     m.LogExportEnabled,
+		CacheEnabled:         m.CacheEnabled,
+		Status:               m.Status,
+		Cdn:                  m.Cdn,
+		CdnStatus:            m.CdnStatus,
+		ForceHttps:           m.ForceHttps,
+		AuditMode:            m.AuditMode,
+		PassthroughMode:      m.PassthroughMode,
+		Hsts:                 m.Hsts,
+		PassTlsClientCert:    m.PassTlsClientCert,
+		TlsOptionsUid:        m.TlsOptionsUid,
+		BlacklistedCountries: m.BlacklistedCountries,
+		Tags:                 m.Tags,
+		LastUpdated:          m.LastUpdated,
+
+		ManageExceptionsAndRules: types.BoolValue(true),
+		IpExceptionsManagement:   types.StringValue(managementAuthoritative),
+		UrlExceptionsManagement:  types.StringValue(managementAuthoritative),
+		RewriteRulesManagement:   types.StringValue(managementAuthoritative),
+		RulesManagement:          types.StringValue(managementAuthoritative),
+		DeletionProtection:       types.BoolValue(false),
+		OnDestroy:                types.StringValue(siteOnDestroyDelete),
+		Timeouts:                 siteTimeoutsNull(),
+	}
+
+	// Brain parameters overrides
+	overrides := map[string]attr.Value{}
+	for key, value := range m.BrainOverrides {
+		overrides[key] = brainOverrideValue{Float64Value: value}
+	}
+	brainOverrides, d := types.MapValue(brainOverrideType{}, overrides)
+	diags.Append(d...)
+	if m.BrainOverrides == nil {
+		brainOverrides = types.MapNull(brainOverrideType{})
+	}
+	state.BrainOverrides = brainOverrides
+
+	// Certificate
+	if c := m.ActiveCustomerCertificate; c != nil {
+		state.ActiveCustomerCertificate = &ActiveCustomerCertificateModel{
+			Cn:           c.Cn,
+			ExpiredAt:    c.ExpiredAt,
+			Hash:         c.Hash,
+			P12File:      c.P12File,
+			P12Content64: c.P12Content64,
+			P12Password:  c.P12Password,
+		}
+	}
+
+	// Exceptions and rules
+	for _, e := range m.IpExceptions {
+		state.IpExceptions = append(state.IpExceptions, IpExceptionModel{Ip: ipRangeValue{StringValue: e.Ip}, Comment: e.Comment})
+	}
+	for _, e := range m.UrlExceptions {
+		state.UrlExceptions = append(state.UrlExceptions, UrlExceptionModel{Path: e.Path, Comment: e.Comment})
+	}
+	for _, e := range m.RewriteRules {
+		state.RewriteRules = append(state.RewriteRules, RewriteRuleModel{
+			Active:             e.Active,
+			Comment:            e.Comment,
+			RewriteSource:      e.RewriteSource,
+			RewriteDestination: e.RewriteDestination,
+		})
+	}
+	for _, e := range m.Rules {
+		var whitelistedIps []ipRangeValue
+		for _, ip := range e.WhitelistedIps {
+			whitelistedIps = append(whitelistedIps, ipRangeValue{StringValue: ip})
+		}
+		state.Rules = append(state.Rules, RuleModel{
+			Active:         e.Active,
+			Action:         e.Action,
+			Cache:          e.Cache,
+			Comment:        e.Comment,
+			Paths:          e.Paths,
+			WhitelistedIps: whitelistedIps,
+		})
+	}
+
+	// DNS records computed from cluster entrypoints
+	site := &ogosecurity.Site{
+		DomainName: m.DomainName.ValueString(),
+		Cdn:        m.Cdn.ValueStringPointer(),
+		Cluster: ogosecurity.Cluster{
+			Entrypoint4:   m.ClusterEntrypoint4.ValueString(),
+			Entrypoint6:   m.ClusterEntrypoint6.ValueString(),
+			EntrypointCdn: m.ClusterEntrypointCdn.ValueString(),
+		},
+	}
+	state.DnsRecords, d = siteDnsRecordsValue(ctx, site)
+	diags.Append(d...)
+
+	return state, diags
+}
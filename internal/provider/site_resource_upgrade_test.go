@@ -0,0 +1,14 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestSiteResourceUpgradeState(t *testing.T) {
+	for version := int64(0); version < siteResourceSchemaVersion; version++ {
+		testUpgradeResourceState(t, "ogo_shield_site", version, siteResourceSchemaVersion)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &siteRewriteRuleResource{}
+	_ resource.ResourceWithConfigure   = &siteRewriteRuleResource{}
+	_ resource.ResourceWithImportState = &siteRewriteRuleResource{}
+)
+
+// SiteRewriteRuleResourceModel maps the resource schema data.
+type SiteRewriteRuleResourceModel struct {
+	DomainName         types.String `tfsdk:"domain_name"`
+	Active             types.Bool   `tfsdk:"active"`
+	Comment            types.String `tfsdk:"comment"`
+	RewriteSource      types.String `tfsdk:"rewrite_source"`
+	RewriteDestination types.String `tfsdk:"rewrite_destination"`
+	LastUpdated        types.String `tfsdk:"last_updated"`
+}
+
+// NewSiteRewriteRuleResource is a helper function to simplify the provider implementation.
+func NewSiteRewriteRuleResource() resource.Resource {
+	return &siteRewriteRuleResource{}
+}
+
+// siteRewriteRuleResource is the resource implementation.
+type siteRewriteRuleResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the resource type name.
+func (r *siteRewriteRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_site_rewrite_rule"
+}
+
+// Schema defines the schema for the resource.
+func (r *siteRewriteRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS domain name of the site to which the rewrite rule is added.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Flag to enable (**true**) or disable (**false**) rewrite rule. (default: **true**).",
+				Default:     booldefault.StaticBool(true),
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description associated with this rewrite rule.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"rewrite_source": schema.StringAttribute{
+				Required:    true,
+				Description: "Source path to be rewritten.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rewrite_destination": schema.StringAttribute{
+				Required:    true,
+				Description: "Rewritten destination path.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last resource updated by Terraform.",
+			},
+		},
+		MarkdownDescription: "Resource `ogo_shield_site_rewrite_rule` manages a single rewrite rule " +
+			"of a site, independently of the `ogo_shield_site` resource.\n\n" +
+			"New rewrite rules are appended after existing site rewrite rules.\n\n" +
+			"Site `rewrite_rules` attribute must not be managed by `ogo_shield_site` resource " +
+			"(see `manage_exceptions_and_rules` attribute) when this resource is used.\n\n",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *siteRewriteRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ogoProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *siteRewriteRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SiteRewriteRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Add rewrite rule
+	rewriteRules := []ogosecurity.RewriteRule{}
+	for _, rewrite := range site.RewriteRules {
+		if rewrite.RewriteSource == plan.RewriteSource.ValueString() {
+			resp.Diagnostics.AddError(
+				"Error creating site rewrite rule",
+				"Rewrite rule "+rewrite.RewriteSource+" already exists on site "+domainName+", import it to manage it with Terraform.",
+			)
+			return
+		}
+		rewriteRules = append(rewriteRules, rewrite)
+	}
+	rewriteRules = append(rewriteRules, ogosecurity.RewriteRule{
+		Active:             plan.Active.ValueBool(),
+		Comment:            plan.Comment.ValueString(),
+		RewriteSource:      plan.RewriteSource.ValueString(),
+		RewriteDestination: plan.RewriteDestination.ValueString(),
+	})
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{RewriteRules: &rewriteRules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating site rewrite rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *siteRewriteRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SiteRewriteRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed site value from Ogo
+	site, err := r.client.GetSite(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+state.DomainName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Find rewrite rule, remove it from state if it no longer exists
+	var rewriteRule *ogosecurity.RewriteRule
+	for i := range site.RewriteRules {
+		if site.RewriteRules[i].RewriteSource == state.RewriteSource.ValueString() {
+			rewriteRule = &site.RewriteRules[i]
+			break
+		}
+	}
+	if rewriteRule == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite properties with refreshed state
+	state.Active = types.BoolValue(rewriteRule.Active)
+	state.RewriteDestination = types.StringValue(rewriteRule.RewriteDestination)
+	if rewriteRule.Comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(rewriteRule.Comment)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *siteRewriteRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SiteRewriteRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Replace rewrite rule
+	rewriteRule := ogosecurity.RewriteRule{
+		Active:             plan.Active.ValueBool(),
+		Comment:            plan.Comment.ValueString(),
+		RewriteSource:      plan.RewriteSource.ValueString(),
+		RewriteDestination: plan.RewriteDestination.ValueString(),
+	}
+
+	found := false
+	rewriteRules := []ogosecurity.RewriteRule{}
+	for _, rewrite := range site.RewriteRules {
+		if rewrite.RewriteSource == rewriteRule.RewriteSource {
+			rewrite = rewriteRule
+			found = true
+		}
+		rewriteRules = append(rewriteRules, rewrite)
+	}
+	if !found {
+		rewriteRules = append(rewriteRules, rewriteRule)
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{RewriteRules: &rewriteRules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating site rewrite rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *siteRewriteRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SiteRewriteRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Remove rewrite rule
+	rewriteRules := []ogosecurity.RewriteRule{}
+	for _, rewrite := range site.RewriteRules {
+		if rewrite.RewriteSource != state.RewriteSource.ValueString() {
+			rewriteRules = append(rewriteRules, rewrite)
+		}
+	}
+	if len(rewriteRules) == len(site.RewriteRules) {
+		return
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{RewriteRules: &rewriteRules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting site rewrite rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *siteRewriteRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format is <domain_name>/<rewrite_source>
+	domainName, rewriteSource, found := strings.Cut(req.ID, "/")
+	if !found || domainName == "" || rewriteSource == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID format <domain_name>/<rewrite_source>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rewrite_source"), rewriteSource)...)
+}
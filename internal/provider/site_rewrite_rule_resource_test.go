@@ -0,0 +1,86 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSiteRewriteRuleResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_rewrite_rule" "api" {
+  domain_name         = ogo_shield_site.foo.domain_name
+  comment             = "API v1 to v2"
+  rewrite_source      = "^/api/v1/(.*)"
+  rewrite_destination = "/api/v2/$1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "domain_name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "active", "true"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "comment", "API v1 to v2"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "rewrite_source", "^/api/v1/(.*)"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "rewrite_destination", "/api/v2/$1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rewrite_rules.#", "0"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ogo_shield_site_rewrite_rule.api", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "ogo_shield_site_rewrite_rule.api",
+				ImportStateId:                        "foo.example.com/^/api/v1/(.*)",
+				ImportStateVerifyIdentifierAttribute: "rewrite_source",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_rewrite_rule" "api" {
+  domain_name         = ogo_shield_site.foo.domain_name
+  active              = false
+  comment             = "API v1 to v2"
+  rewrite_source      = "^/api/v1/(.*)"
+  rewrite_destination = "/api/v2/$1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_rewrite_rule.api", "active", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,218 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// rewriteReference matches submatch references of a rewrite destination, as
+// expanded by regexp.Regexp.Expand.
+var rewriteReference = regexp.MustCompile(`\$(\$|[0-9A-Za-z_]+|\{([^}]*)\}?)`)
+
+// siteRewriteRulesDiagnostics returns findings about site rewrite rules:
+// sources which can't be compiled, destinations referencing unknown
+// submatches, rules rewriting paths which are rewritten again by later rules,
+// and rules looping on each other as Ogo Shield may evaluate rewritten paths
+// again. Rules are analysed in order of priority. Findings are errors when
+// strict is true, warnings otherwise.
+func siteRewriteRulesDiagnostics(ctx context.Context, value attr.Value, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !fullyKnown(ctx, value) {
+		return diags
+	}
+
+	rules, err := siteRewriteRulesFromValue(value)
+	if err != nil {
+		return diags
+	}
+
+	// Rules in order of application, reported by their index in the list
+	order := priorityOrder(rules, siteRewriteRule.priority)
+	rules = sortByPriority(rules, siteRewriteRule.priority)
+
+	// Compile active rules, rules which can't be compiled are skipped by
+	// chain and loop detection.
+	sources := make([]*regexp.Regexp, len(rules))
+	samples := make([]string, len(rules))
+	for i, rule := range rules {
+		if !rule.Active {
+			continue
+		}
+		rulePath := path.Root("rewrite_rules").AtListIndex(order[i])
+
+		source, err := regexp.Compile(rule.RewriteSource)
+		if err != nil {
+			diags.Append(validationDiagnostic(strict,
+				rulePath.AtName("rewrite_source"),
+				"Invalid rewrite source",
+				fmt.Sprintf("Rewrite rule %d rewrite_source is not a valid regular expression: %s.", order[i], err),
+			))
+			continue
+		}
+
+		if err := checkRewriteDestination(source, rule.RewriteDestination); err != nil {
+			diags.Append(validationDiagnostic(strict,
+				rulePath.AtName("rewrite_destination"),
+				"Invalid rewrite destination",
+				fmt.Sprintf("Rewrite rule %d rewrite_destination can't be expanded: %s.", order[i], err),
+			))
+			continue
+		}
+
+		sources[i] = source
+		samples[i] = rewriteReference.ReplaceAllString(rule.RewriteDestination, "")
+	}
+
+	// Rewritten paths, approximated by destinations without submatches,
+	// matching other rules sources
+	edges := make([][]int, len(rules))
+	for i := range rules {
+		if sources[i] == nil {
+			continue
+		}
+		for j := range rules {
+			if sources[j] != nil && sources[j].MatchString(samples[i]) {
+				edges[i] = append(edges[i], j)
+			}
+		}
+	}
+
+	for i := range rules {
+		if sources[i] == nil {
+			continue
+		}
+
+		if cycle := rewriteCycle(edges, i); cycle != nil {
+			diags.Append(validationDiagnostic(strict,
+				path.Root("rewrite_rules").AtListIndex(order[i]),
+				"Rewrite rules loop",
+				fmt.Sprintf("Rewrite rules %s rewrite paths back to paths matched by rewrite rule %d, "+
+					"which may rewrite them endlessly.", formatRewriteChain(cycle, order), order[i]),
+			))
+			continue
+		}
+
+		if chain := rewriteChain(rules, sources, samples, edges, i); chain != nil {
+			diags.Append(validationDiagnostic(strict,
+				path.Root("rewrite_rules").AtListIndex(order[i]).AtName("rewrite_destination"),
+				"Chained rewrite rules",
+				fmt.Sprintf("Paths rewritten by rewrite rule %d to %q are rewritten again by later rules: %s. "+
+					"Set the final destination on rewrite rule %d if this is not intended.",
+					order[i], rules[i].RewriteDestination, formatRewriteChain(chain, order), order[i]),
+			))
+		}
+	}
+
+	return diags
+}
+
+// checkRewriteDestination returns an error if destination references
+// submatches which don't exist in source.
+func checkRewriteDestination(source *regexp.Regexp, destination string) error {
+	for _, reference := range rewriteReference.FindAllStringSubmatch(destination, -1) {
+		name := strings.TrimPrefix(reference[1], "{")
+		if name == "$" {
+			continue
+		}
+		if strings.HasPrefix(reference[1], "{") {
+			if !strings.HasSuffix(name, "}") {
+				return fmt.Errorf("unterminated submatch reference %q", reference[0])
+			}
+			name = strings.TrimSuffix(name, "}")
+		}
+
+		if index, err := strconv.Atoi(name); err == nil {
+			if index > source.NumSubexp() {
+				return fmt.Errorf("reference %q to submatch %d, but rewrite_source only has %d submatches", reference[0], index, source.NumSubexp())
+			}
+			continue
+		}
+		if name == "" {
+			return fmt.Errorf("empty submatch reference %q", reference[0])
+		}
+		if source.SubexpIndex(name) < 0 && name[0] >= '0' && name[0] <= '9' {
+			return fmt.Errorf("reference %q to unknown named submatch %q, use ${1} form to follow a submatch by characters", reference[0], name)
+		}
+		if source.SubexpIndex(name) < 0 {
+			return fmt.Errorf("reference %q to unknown named submatch %q", reference[0], name)
+		}
+	}
+
+	return nil
+}
+
+// rewriteCycle returns rule indexes of a loop starting and ending at rule
+// start, nil if rule start isn't part of a loop. Loops are only reported by
+// their first rule.
+func rewriteCycle(edges [][]int, start int) []int {
+	visited := map[int]bool{}
+
+	var visit func(i int, cycle []int) []int
+	visit = func(i int, cycle []int) []int {
+		for _, j := range edges[i] {
+			if j == start {
+				return append(cycle, j)
+			}
+			if j < start || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if found := visit(j, append(cycle, j)); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	return visit(start, []int{start})
+}
+
+// rewriteChain returns rule indexes of rules applied in order to paths
+// rewritten by rule start, nil if no later rule applies. Chains are only
+// reported by their first rule.
+func rewriteChain(rules []siteRewriteRule, sources []*regexp.Regexp, samples []string, edges [][]int, start int) []int {
+	for i := range start {
+		if slices.Contains(edges[i], start) {
+			return nil
+		}
+	}
+
+	chain := []int{start}
+	rewritten := samples[start]
+	for j := start + 1; j < len(rules); j++ {
+		if sources[j] == nil || !sources[j].MatchString(rewritten) {
+			continue
+		}
+		chain = append(chain, j)
+		rewritten = sources[j].ReplaceAllString(rewritten, rules[j].RewriteDestination)
+	}
+
+	if len(chain) == 1 {
+		return nil
+	}
+
+	return chain
+}
+
+// formatRewriteChain returns rule indexes, in order of application, as a
+// chain of rules identified by their index in the list.
+func formatRewriteChain(chain []int, order []int) string {
+	rules := []string{}
+	for _, i := range chain {
+		rules = append(rules, strconv.Itoa(order[i]))
+	}
+
+	return strings.Join(rules, " → ")
+}
@@ -0,0 +1,134 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testRewriteRuleAttrTypes = map[string]attr.Type{
+	"active":              types.BoolType,
+	"comment":             types.StringType,
+	"rewrite_source":      types.StringType,
+	"rewrite_destination": types.StringType,
+}
+
+func testRewriteRule(active bool, source string, destination string) attr.Value {
+	return types.ObjectValueMust(testRewriteRuleAttrTypes, map[string]attr.Value{
+		"active":              types.BoolValue(active),
+		"comment":             types.StringNull(),
+		"rewrite_source":      types.StringValue(source),
+		"rewrite_destination": types.StringValue(destination),
+	})
+}
+
+func TestSiteRewriteRulesDiagnostics(t *testing.T) {
+	rewriteRules := path.Root("rewrite_rules")
+
+	for name, tc := range map[string]struct {
+		rules    []attr.Value
+		expected map[string]path.Path
+	}{
+		"independent": {
+			rules: []attr.Value{
+				testRewriteRule(true, "^/old/(.*)", "/new/$1"),
+				testRewriteRule(true, "^/from", "/to"),
+				testRewriteRule(true, "^/(?P<lang>en|fr)/home$", "/home?lang=${lang}"),
+			},
+		},
+		"invalid source": {
+			rules: []attr.Value{
+				testRewriteRule(true, "^/api/(", "/"),
+				testRewriteRule(false, "^/disabled/(", "/"),
+			},
+			expected: map[string]path.Path{
+				"Invalid rewrite source": rewriteRules.AtListIndex(0).AtName("rewrite_source"),
+			},
+		},
+		"invalid destination": {
+			rules: []attr.Value{
+				testRewriteRule(true, "^/api/(.*)", "/v2/$2"),
+				testRewriteRule(true, "^/app/(.*)", "/$1x"),
+				testRewriteRule(true, "^/web/(?P<page>.*)", "/${page"),
+			},
+			expected: map[string]path.Path{
+				"Invalid rewrite destination": rewriteRules.AtListIndex(0).AtName("rewrite_destination"),
+			},
+		},
+		"chain": {
+			rules: []attr.Value{
+				testRewriteRule(true, "^/api/v1/(.*)", "/api/v2/$1"),
+				testRewriteRule(true, "^/api/v2/(.*)", "/api/v3/$1"),
+				testRewriteRule(false, "^/api/v3/", "/disabled/"),
+				testRewriteRule(true, "^/api/v3/(.*)", "/v3/$1"),
+			},
+			expected: map[string]path.Path{
+				"Chained rewrite rules": rewriteRules.AtListIndex(0).AtName("rewrite_destination"),
+			},
+		},
+		"loop": {
+			rules: []attr.Value{
+				testRewriteRule(true, "^/a(.*)", "/b$1"),
+				testRewriteRule(true, "^/b(.*)", "/a$1"),
+			},
+			expected: map[string]path.Path{
+				"Rewrite rules loop": rewriteRules.AtListIndex(0),
+			},
+		},
+		"self loop": {
+			rules: []attr.Value{
+				testRewriteRule(true, "/api", "/v2/api"),
+			},
+			expected: map[string]path.Path{
+				"Rewrite rules loop": rewriteRules.AtListIndex(0),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rules := types.ListValueMust(types.ObjectType{AttrTypes: testRewriteRuleAttrTypes}, tc.rules)
+
+			diags := siteRewriteRulesDiagnostics(context.Background(), rules, false)
+			actual := map[string]path.Path{}
+			for _, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || d.Severity() != diag.SeverityWarning {
+					t.Fatalf("expected warnings with attribute path, got %v", d)
+				}
+				if _, ok := actual[d.Summary()]; ok && d.Summary() != "Invalid rewrite destination" {
+					t.Errorf("unexpected duplicate %s warning: %s", d.Summary(), d.Detail())
+				}
+				if _, ok := actual[d.Summary()]; !ok {
+					actual[d.Summary()] = withPath.Path()
+				}
+			}
+
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("expected %d findings, got %v", len(tc.expected), diags)
+			}
+			for summary, expected := range tc.expected {
+				if !actual[summary].Equal(expected) {
+					t.Errorf("expected %s warning at %s, got %v", summary, expected, diags)
+				}
+			}
+		})
+	}
+}
+
+func TestSiteRewriteRulesDiagnosticsStrict(t *testing.T) {
+	rules := types.ListValueMust(types.ObjectType{AttrTypes: testRewriteRuleAttrTypes}, []attr.Value{
+		testRewriteRule(true, "^/a(.*)", "/b$1"),
+		testRewriteRule(true, "^/b(.*)", "/a$1"),
+	})
+
+	diags := siteRewriteRulesDiagnostics(context.Background(), rules, true)
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 0 {
+		t.Errorf("expected 1 error in strict mode, got %v", diags)
+	}
+}
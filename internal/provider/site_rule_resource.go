@@ -0,0 +1,425 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &siteRuleResource{}
+	_ resource.ResourceWithConfigure   = &siteRuleResource{}
+	_ resource.ResourceWithImportState = &siteRuleResource{}
+)
+
+// SiteRuleResourceModel maps the resource schema data.
+type SiteRuleResourceModel struct {
+	DomainName     types.String   `tfsdk:"domain_name"`
+	Active         types.Bool     `tfsdk:"active"`
+	Action         types.String   `tfsdk:"action"`
+	Cache          types.Bool     `tfsdk:"cache"`
+	Comment        types.String   `tfsdk:"comment"`
+	Paths          []types.String `tfsdk:"paths"`
+	WhitelistedIps []ipRangeValue `tfsdk:"whitelisted_ips"`
+	LastUpdated    types.String   `tfsdk:"last_updated"`
+}
+
+// NewSiteRuleResource is a helper function to simplify the provider implementation.
+func NewSiteRuleResource() resource.Resource {
+	return &siteRuleResource{}
+}
+
+// siteRuleResource is the resource implementation.
+type siteRuleResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the resource type name.
+func (r *siteRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_site_rule"
+}
+
+// Schema defines the schema for the resource.
+func (r *siteRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS domain name of the site to which the rule is added.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Flag to enable (**true**) or disable (**false**) rule. (default: **true**).",
+				Default:     booldefault.StaticBool(true),
+			},
+			"action": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Action to be applied when the rule matches (default: **brain**). Supported values:\n" +
+					"  * **brain**: Rule analyzed by Ogo Shield brain\n" +
+					"  * **bypass**: Rule not analyzed by Ogo Shield brain.",
+				Default: stringdefault.StaticString("brain"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("brain", "bypass"),
+				},
+			},
+			"cache": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Enable or disable caching on this rule. Option can be used only if site caching is enabled. (default: **false**).",
+				Default:     booldefault.StaticBool(false),
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description associated with this rule.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"paths": schema.SetAttribute{
+				Required:    true,
+				Description: "List of URL paths for which the rule is applied, as RE2 regular expressions matched from the start of request paths (e.g. `/admin` matches `/admin/login`). Paths identify the rule on the site.",
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(pathRegexp()),
+				},
+			},
+			"whitelisted_ips": schema.SetAttribute{
+				Required:    true,
+				Description: "Authorized IPv4 or IPv6 addresses or CIDR ranges list.",
+				ElementType: ipRangeType{},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(ipRange()),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last resource updated by Terraform.",
+			},
+		},
+		MarkdownDescription: "Resource `ogo_shield_site_rule` manages a single access rule " +
+			"of a site, independently of the `ogo_shield_site` resource.\n\n" +
+			"New rules are appended after existing site rules. As the engine stops at the first " +
+			"URL match, a rule may never be reached if a previous rule already matches its paths.\n\n" +
+			"Site `rules` attribute must not be managed by `ogo_shield_site` resource " +
+			"(see `manage_exceptions_and_rules` attribute) when this resource is used.\n\n",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *siteRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ogoProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *siteRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SiteRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Add rule
+	rule := plan.toRule()
+	rules := []ogosecurity.Rule{}
+	for _, siteRule := range site.Rules {
+		if sameRulePaths(siteRule.Paths, rule.Paths) {
+			resp.Diagnostics.AddError(
+				"Error creating site rule",
+				"Rule for paths "+strings.Join(siteRule.Paths, ",")+" already exists on site "+domainName+", import it to manage it with Terraform.",
+			)
+			return
+		}
+		rules = append(rules, siteRule)
+	}
+	rules = append(rules, rule)
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{Rules: &rules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating site rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *siteRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SiteRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed site value from Ogo
+	site, err := r.client.GetSite(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+state.DomainName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Find rule, remove it from state if it no longer exists
+	paths := state.toRule().Paths
+	var rule *ogosecurity.Rule
+	for i := range site.Rules {
+		if sameRulePaths(site.Rules[i].Paths, paths) {
+			rule = &site.Rules[i]
+			break
+		}
+	}
+	if rule == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite properties with refreshed state
+	state.Active = types.BoolValue(rule.Active)
+	state.Action = types.StringValue(rule.Action)
+	state.Cache = types.BoolValue(rule.Cache)
+	if rule.Comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(rule.Comment)
+	}
+
+	state.WhitelistedIps = []ipRangeValue{}
+	for _, ip := range rule.WhitelistedIps {
+		state.WhitelistedIps = append(state.WhitelistedIps, ipRangeStringValue(ip))
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *siteRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SiteRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Replace rule
+	rule := plan.toRule()
+	found := false
+	rules := []ogosecurity.Rule{}
+	for _, siteRule := range site.Rules {
+		if sameRulePaths(siteRule.Paths, rule.Paths) {
+			siteRule = rule
+			found = true
+		}
+		rules = append(rules, siteRule)
+	}
+	if !found {
+		rules = append(rules, rule)
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{Rules: &rules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating site rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *siteRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SiteRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Remove rule
+	paths := state.toRule().Paths
+	rules := []ogosecurity.Rule{}
+	for _, siteRule := range site.Rules {
+		if !sameRulePaths(siteRule.Paths, paths) {
+			rules = append(rules, siteRule)
+		}
+	}
+	if len(rules) == len(site.Rules) {
+		return
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{Rules: &rules})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting site rule",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *siteRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format is <domain_name>/<path>[,<path>...]
+	domainName, paths, found := strings.Cut(req.ID, "/")
+	if !found || domainName == "" || paths == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID format <domain_name>/<path>[,<path>...], got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("paths"), strings.Split(paths, ","))...)
+}
+
+// toRule converts the resource model to Ogo rule.
+func (m SiteRuleResourceModel) toRule() ogosecurity.Rule {
+	rule := ogosecurity.Rule{
+		Active:         m.Active.ValueBool(),
+		Action:         m.Action.ValueString(),
+		Cache:          m.Cache.ValueBool(),
+		Comment:        m.Comment.ValueString(),
+		Paths:          []string{},
+		WhitelistedIps: []string{},
+	}
+
+	for _, path := range m.Paths {
+		rule.Paths = append(rule.Paths, path.ValueString())
+	}
+
+	for _, ip := range m.WhitelistedIps {
+		rule.WhitelistedIps = append(rule.WhitelistedIps, ip.ValueString())
+	}
+
+	return rule
+}
+
+// sameRulePaths returns true if both rules apply to the same set of paths.
+func sameRulePaths(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
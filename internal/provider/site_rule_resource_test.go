@@ -0,0 +1,89 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSiteRuleResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_rule" "admin" {
+  domain_name     = ogo_shield_site.foo.domain_name
+  comment         = "Admin access"
+  paths           = ["/admin", "/wp-admin"]
+  whitelisted_ips = ["192.0.2.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "domain_name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "active", "true"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "action", "brain"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "cache", "false"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "comment", "Admin access"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "paths.#", "2"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "whitelisted_ips.#", "1"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "rules.#", "0"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ogo_shield_site_rule.admin", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "ogo_shield_site_rule.admin",
+				ImportStateId:                        "foo.example.com//admin,/wp-admin",
+				ImportStateVerifyIdentifierAttribute: "domain_name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_rule" "admin" {
+  domain_name     = ogo_shield_site.foo.domain_name
+  action          = "bypass"
+  comment         = "Admin access"
+  paths           = ["/admin", "/wp-admin"]
+  whitelisted_ips = ["192.0.2.0/24", "198.51.100.7"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "action", "bypass"),
+					resource.TestCheckResourceAttr("ogo_shield_site_rule.admin", "whitelisted_ips.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
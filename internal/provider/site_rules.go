@@ -0,0 +1,207 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// rulePathRegexpChars are the characters which make a rule path a regular
+// expression rather than a plain prefix.
+const rulePathRegexpChars = `^$*+?()[]{}|\`
+
+// siteRulesDiagnostics returns findings about site rules which are never
+// applied, duplicated or which overlap with conflicting settings. Rules are
+// first-match in order of priority, so a rule is shadowed when all its paths
+// are already matched by earlier active rules. Findings are errors when
+// strict is true, warnings otherwise.
+func siteRulesDiagnostics(ctx context.Context, value attr.Value, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !fullyKnown(ctx, value) {
+		return diags
+	}
+
+	rules, err := siteRulesFromValue(value)
+	if err != nil {
+		return diags
+	}
+
+	// Rules in order of application, reported by their index in the list
+	order := priorityOrder(rules, siteRule.priority)
+	applied := make([]siteRule, len(order))
+	for k, i := range order {
+		applied[k] = rules[i]
+	}
+
+	for k, i := range order {
+		rule := rules[i]
+		rulePath := path.Root("rules").AtListIndex(i)
+
+		if j := slices.IndexFunc(applied[:k], rule.equal); j >= 0 {
+			diags.Append(validationDiagnostic(strict,
+				rulePath,
+				"Duplicate site rule",
+				fmt.Sprintf("Rule %d is identical to rule %d and can be removed.", i, order[j]),
+			))
+			continue
+		}
+
+		if !rule.Active {
+			continue
+		}
+
+		shadowing := map[int][]string{}
+		conflicts := []string{}
+		for _, p := range rule.Paths {
+			j, earlierPath := matchRulePath(applied[:k], p)
+			if j < 0 {
+				continue
+			}
+			shadowing[order[j]] = append(shadowing[order[j]], fmt.Sprintf("%q", p))
+			if applied[j].Action != rule.Action || !sameIpRanges(applied[j].WhitelistedIps, rule.WhitelistedIps) {
+				conflicts = append(conflicts, fmt.Sprintf("path %q is matched by path %q of rule %d", p, earlierPath, order[j]))
+			}
+		}
+
+		if len(rule.Paths) > 0 && countValues(shadowing) == len(rule.Paths) {
+			details := []string{}
+			for _, j := range sortedKeys(shadowing) {
+				details = append(details, fmt.Sprintf("%s by rule %d", strings.Join(shadowing[j], ", "), j))
+			}
+			diags.Append(validationDiagnostic(strict,
+				rulePath,
+				"Unreachable site rule",
+				fmt.Sprintf("Rule %d is never applied, as rules are parsed in order of priority and declaration and all its paths "+
+					"are already matched by earlier rules: %s. Move the rule before, lower its priority or remove it.", i, strings.Join(details, "; ")),
+			))
+			continue
+		}
+
+		if len(conflicts) > 0 {
+			diags.Append(validationDiagnostic(strict,
+				rulePath.AtName("paths"),
+				"Overlapping site rules",
+				fmt.Sprintf("Rule %d overlaps with earlier rules with a different action or whitelisted_ips, "+
+					"which are applied instead: %s.", i, strings.Join(conflicts, "; ")),
+			))
+		}
+	}
+
+	return diags
+}
+
+// fullyKnown returns true if value is set and fully known, so that it can be
+// analysed at plan time.
+func fullyKnown(ctx context.Context, value attr.Value) bool {
+	if value == nil || value.IsNull() {
+		return false
+	}
+	raw, err := value.ToTerraformValue(ctx)
+
+	return err == nil && raw.IsFullyKnown()
+}
+
+// matchRulePath returns index and path of the first active rule matching
+// all requests of path p, index is -1 if no rule matches.
+func matchRulePath(rules []siteRule, p string) (int, string) {
+	for i, rule := range rules {
+		if !rule.Active {
+			continue
+		}
+		for _, rulePath := range rule.Paths {
+			if rulePathCovers(rulePath, p) {
+				return i, rulePath
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+// rulePathMatches returns true if requestPath matches rulePath. Rule paths
+// are RE2 regular expressions matched from the start of request paths, so
+// that plain paths match as prefixes.
+func rulePathMatches(rulePath string, requestPath string) bool {
+	re, err := regexp.Compile(`^(?:` + rulePath + `)`)
+
+	return err == nil && re.MatchString(requestPath)
+}
+
+// rulePathCovers returns true if all requests matching path p also match
+// rulePath. Paths with regular expression characters can't be compared as
+// prefixes, they only cover identical paths.
+func rulePathCovers(rulePath string, p string) bool {
+	if rulePath == p {
+		return true
+	}
+	if strings.ContainsAny(rulePath, rulePathRegexpChars) || strings.ContainsAny(p, rulePathRegexpChars) {
+		return false
+	}
+
+	return rulePathMatches(rulePath, p)
+}
+
+// equal returns true if both rules have the same settings, whatever the
+// order of paths and whitelisted IPs.
+func (r siteRule) equal(o siteRule) bool {
+	return r.Active == o.Active &&
+		r.Action == o.Action &&
+		r.Cache == o.Cache &&
+		sameElements(r.Paths, o.Paths) &&
+		sameIpRanges(r.WhitelistedIps, o.WhitelistedIps)
+}
+
+// sameElements returns true if both lists have the same elements, whatever
+// their order.
+func sameElements(a []string, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// sameIpRanges returns true if both lists have the same IP ranges, whatever
+// their order and notation.
+func sameIpRanges(a []string, b []string) bool {
+	canonical := func(ips []string) []string {
+		ranges := []string{}
+		for _, ip := range ips {
+			ranges = append(ranges, canonicalIpRange(ip))
+		}
+		return ranges
+	}
+
+	return sameElements(canonical(a), canonical(b))
+}
+
+// countValues returns the number of values of m.
+func countValues(m map[int][]string) int {
+	count := 0
+	for _, values := range m {
+		count += len(values)
+	}
+
+	return count
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys(m map[int][]string) []int {
+	keys := []int{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
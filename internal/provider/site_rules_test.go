@@ -0,0 +1,217 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testRuleAttrTypes = map[string]attr.Type{
+	"active":          types.BoolType,
+	"action":          types.StringType,
+	"cache":           types.BoolType,
+	"priority":        types.Int64Type,
+	"comment":         types.StringType,
+	"paths":           types.SetType{ElemType: types.StringType},
+	"whitelisted_ips": types.SetType{ElemType: types.StringType},
+}
+
+func testRule(active bool, action string, paths []string, whitelistedIps []string) attr.Value {
+	return types.ObjectValueMust(testRuleAttrTypes, map[string]attr.Value{
+		"active":          types.BoolValue(active),
+		"action":          types.StringValue(action),
+		"cache":           types.BoolValue(false),
+		"priority":        types.Int64Null(),
+		"comment":         types.StringNull(),
+		"paths":           testStringSet(paths),
+		"whitelisted_ips": testStringSet(whitelistedIps),
+	})
+}
+
+func testRuleWithPriority(rule attr.Value, priority int64) attr.Value {
+	attributes := rule.(types.Object).Attributes()
+	attributes["priority"] = types.Int64Value(priority)
+
+	return types.ObjectValueMust(testRuleAttrTypes, attributes)
+}
+
+func testStringSet(values []string) attr.Value {
+	elements := []attr.Value{}
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func TestSiteRulesDiagnostics(t *testing.T) {
+	office := []string{"10.10.9.0/24"}
+
+	for name, tc := range map[string]struct {
+		rules    []attr.Value
+		expected []diag.Diagnostic
+	}{
+		"ordered": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/api/admin"}, office),
+				testRule(true, "bypass", []string{"/api"}, []string{}),
+				testRule(true, "brain", []string{"/"}, []string{}),
+			},
+		},
+		"shadowed": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/"}, []string{}),
+				testRule(true, "bypass", []string{"/api", "/static"}, []string{}),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(1), "Unreachable site rule", ""),
+			},
+		},
+		"shadowed by several rules": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/api"}, office),
+				testRule(true, "brain", []string{"/static"}, []string{}),
+				testRule(true, "brain", []string{"/api/v1", "/static/img"}, []string{}),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(2), "Unreachable site rule", ""),
+			},
+		},
+		"shadowed by priority": {
+			rules: []attr.Value{
+				testRule(true, "bypass", []string{"/api"}, []string{}),
+				testRuleWithPriority(testRule(true, "brain", []string{"/"}, []string{}), 1),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(0), "Unreachable site rule", ""),
+			},
+		},
+		"ordered by priority": {
+			rules: []attr.Value{
+				testRuleWithPriority(testRule(true, "brain", []string{"/"}, []string{}), 20),
+				testRuleWithPriority(testRule(true, "bypass", []string{"/api"}, []string{}), 10),
+			},
+		},
+		"shadowed by inactive rule": {
+			rules: []attr.Value{
+				testRule(false, "brain", []string{"/"}, []string{}),
+				testRule(true, "bypass", []string{"/api"}, []string{}),
+			},
+		},
+		"overlapping": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/admin"}, office),
+				testRule(true, "brain", []string{"/admin/health", "/monitor"}, []string{"10.10.10.1"}),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(1).AtName("paths"), "Overlapping site rules", ""),
+			},
+		},
+		"overlapping with same settings": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/admin"}, office),
+				testRule(true, "brain", []string{"/admin/health", "/monitor"}, office),
+			},
+		},
+		"overlapping with same IP ranges notations": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"/admin"}, []string{"10.10.10.1", "2001:db8::/64"}),
+				testRule(true, "brain", []string{"/admin/health", "/monitor"}, []string{"2001:DB8::/64", "10.10.10.1/32"}),
+			},
+		},
+		"duplicate": {
+			rules: []attr.Value{
+				testRule(false, "brain", []string{"/admin", "/wp-admin"}, office),
+				testRule(true, "brain", []string{"/monitor"}, office),
+				testRule(false, "brain", []string{"/wp-admin", "/admin"}, office),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(2), "Duplicate site rule", ""),
+			},
+		},
+		"regular expression": {
+			rules: []attr.Value{
+				testRule(true, "brain", []string{"^/api/v[0-9]+"}, []string{}),
+				testRule(true, "bypass", []string{"^/api/v[0-9]+/public"}, []string{}),
+				testRule(true, "bypass", []string{"^/api/v[0-9]+"}, []string{}),
+			},
+			expected: []diag.Diagnostic{
+				diag.NewAttributeWarningDiagnostic(path.Root("rules").AtListIndex(2), "Unreachable site rule", ""),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rules := types.ListValueMust(types.ObjectType{AttrTypes: testRuleAttrTypes}, tc.rules)
+
+			diags := siteRulesDiagnostics(context.Background(), rules, false)
+			if len(diags) != len(tc.expected) {
+				t.Fatalf("expected %d diagnostics, got %d: %v", len(tc.expected), len(diags), diags)
+			}
+			for i, expected := range tc.expected {
+				actual, ok := diags[i].(diag.DiagnosticWithPath)
+				if !ok || actual.Severity() != diag.SeverityWarning || actual.Summary() != expected.Summary() ||
+					!actual.Path().Equal(expected.(diag.DiagnosticWithPath).Path()) {
+					t.Errorf("expected %s warning at %s, got %v", expected.Summary(), expected.(diag.DiagnosticWithPath).Path(), diags[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSiteRulesDiagnosticsUnknown(t *testing.T) {
+	rules := types.ListValueMust(types.ObjectType{AttrTypes: testRuleAttrTypes}, []attr.Value{
+		testRule(true, "brain", []string{"/"}, []string{}),
+		types.ObjectUnknown(testRuleAttrTypes),
+	})
+
+	if diags := siteRulesDiagnostics(context.Background(), rules, false); len(diags) > 0 {
+		t.Errorf("expected no diagnostics for unknown rules, got %v", diags)
+	}
+}
+
+func TestRulePathMatches(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rulePath    string
+		requestPath string
+		matches     bool
+	}{
+		"plain prefix":        {rulePath: "/admin", requestPath: "/admin/login", matches: true},
+		"plain not prefix":    {rulePath: "/admin", requestPath: "/wp/admin", matches: false},
+		"anchored":            {rulePath: "^/admin", requestPath: "/admin/login", matches: true},
+		"anchored exact":      {rulePath: "^/admin$", requestPath: "/admin", matches: true},
+		"anchored exact only": {rulePath: "^/admin$", requestPath: "/admin/login", matches: false},
+		"alternation":         {rulePath: "/(wp-)?admin", requestPath: "/wp-admin/index.php", matches: true},
+		"invalid":             {rulePath: "/admin(", requestPath: "/admin(", matches: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := rulePathMatches(tc.rulePath, tc.requestPath); actual != tc.matches {
+				t.Errorf("expected %q matching %q to be %t, got %t", tc.rulePath, tc.requestPath, tc.matches, actual)
+			}
+		})
+	}
+}
+
+func TestMatchRule(t *testing.T) {
+	rules := []siteRule{
+		{Active: true, Action: "block", Paths: []string{"^/admin$"}},
+		{Active: true, Action: "brain", Paths: []string{"^/admin/"}},
+		{Active: true, Action: "bypass", Paths: []string{"/"}},
+	}
+
+	for requestPath, expected := range map[string]int{
+		"/admin":       0,
+		"/admin/login": 1,
+		"/index.html":  2,
+	} {
+		if actual, _ := matchRule(rules, requestPath); actual != expected {
+			t.Errorf("expected %q to match rule %d, got %d", requestPath, expected, actual)
+		}
+	}
+}
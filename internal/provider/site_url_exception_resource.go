@@ -0,0 +1,334 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &siteUrlExceptionResource{}
+	_ resource.ResourceWithConfigure   = &siteUrlExceptionResource{}
+	_ resource.ResourceWithImportState = &siteUrlExceptionResource{}
+)
+
+// SiteUrlExceptionResourceModel maps the resource schema data.
+type SiteUrlExceptionResourceModel struct {
+	DomainName  types.String `tfsdk:"domain_name"`
+	Path        types.String `tfsdk:"path"`
+	Comment     types.String `tfsdk:"comment"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// NewSiteUrlExceptionResource is a helper function to simplify the provider implementation.
+func NewSiteUrlExceptionResource() resource.Resource {
+	return &siteUrlExceptionResource{}
+}
+
+// siteUrlExceptionResource is the resource implementation.
+type siteUrlExceptionResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the resource type name.
+func (r *siteUrlExceptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_site_url_exception"
+}
+
+// Schema defines the schema for the resource.
+func (r *siteUrlExceptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS domain name of the site to which the URL exception is added.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Path of the URL never blocked by Ogo Shield, as a regular expression.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					pathRegexp(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description associated with this URL exception.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last resource updated by Terraform.",
+			},
+		},
+		MarkdownDescription: "Resource `ogo_shield_site_url_exception` manages a single URL exception " +
+			"of a site, independently of the `ogo_shield_site` resource.\n\n" +
+			"Site `url_exceptions` attribute must not be managed by `ogo_shield_site` resource " +
+			"(see `manage_exceptions_and_rules` attribute) when this resource is used.\n\n",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *siteUrlExceptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ogoProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *siteUrlExceptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SiteUrlExceptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Add URL exception
+	urlExceptions := []ogosecurity.UrlException{}
+	for _, url := range site.UrlExceptions {
+		if url.Path == plan.Path.ValueString() {
+			resp.Diagnostics.AddError(
+				"Error creating site URL exception",
+				"URL exception "+url.Path+" already exists on site "+domainName+", import it to manage it with Terraform.",
+			)
+			return
+		}
+		urlExceptions = append(urlExceptions, url)
+	}
+	urlExceptions = append(urlExceptions, ogosecurity.UrlException{
+		Path:    plan.Path.ValueString(),
+		Comment: plan.Comment.ValueString(),
+	})
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{UrlExceptions: &urlExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating site URL exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *siteUrlExceptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SiteUrlExceptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed site value from Ogo
+	site, err := r.client.GetSite(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+state.DomainName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Find URL exception, remove it from state if it no longer exists
+	var urlException *ogosecurity.UrlException
+	for i := range site.UrlExceptions {
+		if site.UrlExceptions[i].Path == state.Path.ValueString() {
+			urlException = &site.UrlExceptions[i]
+			break
+		}
+	}
+	if urlException == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite properties with refreshed state
+	if urlException.Comment != "" || !state.Comment.IsNull() {
+		state.Comment = types.StringValue(urlException.Comment)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *siteUrlExceptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SiteUrlExceptionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Replace URL exception
+	found := false
+	urlExceptions := []ogosecurity.UrlException{}
+	for _, url := range site.UrlExceptions {
+		if url.Path == plan.Path.ValueString() {
+			url.Comment = plan.Comment.ValueString()
+			found = true
+		}
+		urlExceptions = append(urlExceptions, url)
+	}
+	if !found {
+		urlExceptions = append(urlExceptions, ogosecurity.UrlException{
+			Path:    plan.Path.ValueString(),
+			Comment: plan.Comment.ValueString(),
+		})
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{UrlExceptions: &urlExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating site URL exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *siteUrlExceptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SiteUrlExceptionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.DomainName.ValueString()
+	defer lockSite(domainName)()
+
+	site, err := r.client.GetSite(domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Ogo site",
+			"Could not read Ogo site domain name "+domainName+": "+err.Error(),
+		)
+		return
+	}
+
+	// Remove URL exception
+	urlExceptions := []ogosecurity.UrlException{}
+	for _, url := range site.UrlExceptions {
+		if url.Path != state.Path.ValueString() {
+			urlExceptions = append(urlExceptions, url)
+		}
+	}
+	if len(urlExceptions) == len(site.UrlExceptions) {
+		return
+	}
+
+	_, err = r.client.PatchSite(domainName, ogosecurity.SitePatch{UrlExceptions: &urlExceptions})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting site URL exception",
+			"Could not update site, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *siteUrlExceptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format is <domain_name>/<path>
+	domainName, urlPath, found := strings.Cut(req.ID, "/")
+	if !found || domainName == "" || urlPath == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID format <domain_name>/<path>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), urlPath)...)
+}
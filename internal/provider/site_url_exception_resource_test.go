@@ -0,0 +1,83 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSiteUrlExceptionResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+}
+
+resource "ogo_shield_site_url_exception" "healthz" {
+  domain_name = ogo_shield_site.foo.domain_name
+  path        = "/healthz"
+  comment     = "Health check"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_url_exception.healthz", "domain_name", "foo.example.com"),
+					resource.TestCheckResourceAttr("ogo_shield_site_url_exception.healthz", "path", "/healthz"),
+					resource.TestCheckResourceAttr("ogo_shield_site_url_exception.healthz", "comment", "Health check"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "url_exceptions.#", "0"),
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ogo_shield_site_url_exception.healthz", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "ogo_shield_site_url_exception.healthz",
+				ImportStateId:                        "foo.example.com//healthz",
+				ImportStateVerifyIdentifierAttribute: "path",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ogo_shield_site" "foo" {
+  domain_name                 = "foo.example.com"
+  cluster_uid                 = "` + clusterUid + `"
+  origin_server               = "172.18.1.12"
+  manage_exceptions_and_rules = false
+  force_https                 = true
+}
+
+resource "ogo_shield_site_url_exception" "healthz" {
+  domain_name = ogo_shield_site.foo.domain_name
+  path        = "/healthz"
+  comment     = "Load balancer health check"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_site_url_exception.healthz", "comment", "Load balancer health check"),
+					resource.TestCheckResourceAttr("ogo_shield_site.foo", "force_https", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Site readiness polling settings.
+const (
+	siteDefaultTimeout = 30 * time.Minute
+	siteWaitMinDelay   = 5 * time.Second
+	siteWaitMaxDelay   = 1 * time.Minute
+)
+
+// siteTimeoutsNull returns timeouts of a site without configured timeouts.
+func siteTimeoutsNull() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+		}),
+	}
+}
+
+// SiteWaitForModel maps site readiness conditions.
+type SiteWaitForModel struct {
+	Status    []types.String `tfsdk:"status"`
+	CdnStatus []types.String `tfsdk:"cdn_status"`
+}
+
+// siteWaitTimeoutError is returned when a site doesn't reach expected
+// conditions before timeout.
+type siteWaitTimeoutError struct {
+	conditions string
+	site       *ogosecurity.Site
+}
+
+func (e *siteWaitTimeoutError) Error() string {
+	return fmt.Sprintf("site %s didn't reach expected state (%s) before timeout, last observed %s",
+		e.site.DomainName, e.conditions, siteObservedStatus(e.site))
+}
+
+// conditions returns a human readable description of readiness conditions.
+func (m *SiteWaitForModel) conditions() string {
+	conditions := []string{}
+	if len(m.Status) > 0 {
+		conditions = append(conditions, "status in ["+joinStrings(m.Status)+"]")
+	}
+	if len(m.CdnStatus) > 0 {
+		conditions = append(conditions, "cdn_status in ["+joinStrings(m.CdnStatus)+"]")
+	}
+
+	return strings.Join(conditions, ", ")
+}
+
+// ready returns true if site meets readiness conditions.
+func (m *SiteWaitForModel) ready(site *ogosecurity.Site) bool {
+	if len(m.Status) > 0 && !containsString(m.Status, site.Status) {
+		return false
+	}
+
+	if len(m.CdnStatus) > 0 && (site.CdnStatus == nil || !containsString(m.CdnStatus, *site.CdnStatus)) {
+		return false
+	}
+
+	return true
+}
+
+// waitForSite waits for site to meet readiness conditions and returns the
+// last observed site. Timeout is reported as a warning when warnOnTimeout is
+// true, so that a site created but slow to provision is not tainted and
+// replaced on next apply.
+func (r *siteResource) waitForSite(ctx context.Context, waitFor *SiteWaitForModel, timeout time.Duration, site *ogosecurity.Site, warnOnTimeout bool) (*ogosecurity.Site, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if waitFor == nil {
+		return site, diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ready, err := pollSite(ctx, r.client, site.DomainName, waitFor)
+	var timeoutErr *siteWaitTimeoutError
+	switch {
+	case errors.As(err, &timeoutErr) && warnOnTimeout:
+		diags.AddWarning(
+			"Timeout waiting for site",
+			"Site "+site.DomainName+" has been created but didn't reach expected state ("+timeoutErr.conditions+") within "+timeout.String()+
+				", last observed "+siteObservedStatus(timeoutErr.site)+". Site is kept, check its status with the next plan.",
+		)
+		return timeoutErr.site, diags
+	case errors.As(err, &timeoutErr):
+		diags.AddError(
+			"Timeout waiting for site",
+			"Site "+site.DomainName+" didn't reach expected state ("+timeoutErr.conditions+") within "+timeout.String()+
+				", last observed "+siteObservedStatus(timeoutErr.site)+".",
+		)
+		return timeoutErr.site, diags
+	case err != nil:
+		diags.AddError(
+			"Error waiting for site",
+			"Could not read Ogo site domain name "+site.DomainName+": "+err.Error(),
+		)
+		return site, diags
+	}
+
+	return ready, diags
+}
+
+// pollSite polls site until it meets readiness conditions or context is
+// done. Polling delay is doubled after each attempt.
+func pollSite(ctx context.Context, client *ogosecurity.Client, domainName string, waitFor *SiteWaitForModel) (*ogosecurity.Site, error) {
+	delay := siteWaitMinDelay
+	for {
+		site, err := client.GetSite(domainName)
+		if err != nil {
+			return nil, err
+		}
+
+		if waitFor.ready(site) {
+			return site, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return site, &siteWaitTimeoutError{conditions: waitFor.conditions(), site: site}
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, siteWaitMaxDelay)
+	}
+}
+
+// siteObservedStatus returns a human readable site status.
+func siteObservedStatus(site *ogosecurity.Site) string {
+	observed := "status " + site.Status
+	if site.CdnStatus != nil {
+		observed += ", cdn_status " + *site.CdnStatus
+	}
+
+	return observed
+}
+
+func containsString(values []types.String, value string) bool {
+	return slices.ContainsFunc(values, func(v types.String) bool {
+		return v.ValueString() == value
+	})
+}
+
+func joinStrings(values []types.String) string {
+	s := []string{}
+	for _, v := range values {
+		s = append(s, v.ValueString())
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,120 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSiteWaitFor returns the wait_for status set documented for sites
+// served with a certificate.
+func testSiteWaitFor() *SiteWaitForModel {
+	return &SiteWaitForModel{
+		Status: []types.String{types.StringValue("LE_CERT"), types.StringValue("CUST_CERT")},
+	}
+}
+
+// testSiteWaitResource returns a site resource whose client always reads
+// site with the given status.
+func testSiteWaitResource(t *testing.T, status string) *siteResource {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"domainName": "foo.example.com", "status": "` + status + `", "cdnStatus": "ACTIVE"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, email, apikey, organization := server.URL, "user@example.com", "key", "org"
+	client, err := ogosecurity.NewClient(&endpoint, &email, &apikey, &organization)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return &siteResource{client: client}
+}
+
+func TestSiteWaitForReady(t *testing.T) {
+	active, inProgress := "ACTIVE", "ACTIVATION_IN_PROGRESS"
+	cdnWaitFor := &SiteWaitForModel{
+		Status:    testSiteWaitFor().Status,
+		CdnStatus: []types.String{types.StringValue("ACTIVE")},
+	}
+
+	for name, tc := range map[string]struct {
+		waitFor *SiteWaitForModel
+		site    ogosecurity.Site
+		ready   bool
+	}{
+		"created":                {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "CREATED"}},
+		"dns error":              {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "DNS_ERROR"}},
+		"online":                 {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "ONLINE"}},
+		"offline":                {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "OFFLINE"}},
+		"let's encrypt":          {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "LE_CERT"}, ready: true},
+		"customer certificate":   {waitFor: testSiteWaitFor(), site: ogosecurity.Site{Status: "CUST_CERT"}, ready: true},
+		"cdn activation":         {waitFor: cdnWaitFor, site: ogosecurity.Site{Status: "LE_CERT", CdnStatus: &inProgress}},
+		"cdn without cdn status": {waitFor: cdnWaitFor, site: ogosecurity.Site{Status: "LE_CERT"}},
+		"cdn active":             {waitFor: cdnWaitFor, site: ogosecurity.Site{Status: "LE_CERT", CdnStatus: &active}, ready: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if ready := tc.waitFor.ready(&tc.site); ready != tc.ready {
+				t.Errorf("expected ready %t, got %t", tc.ready, ready)
+			}
+		})
+	}
+}
+
+func TestWaitForSite(t *testing.T) {
+	r := testSiteWaitResource(t, "LE_CERT")
+	site := &ogosecurity.Site{DomainName: "foo.example.com", Status: "CREATED"}
+
+	observed, diags := r.waitForSite(context.Background(), testSiteWaitFor(), time.Minute, site, false)
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+	if observed.Status != "LE_CERT" {
+		t.Errorf("expected observed status LE_CERT, got %s", observed.Status)
+	}
+
+	// Without wait_for, site is returned as created
+	observed, diags = r.waitForSite(context.Background(), nil, time.Minute, site, false)
+	if diags.HasError() || observed != site {
+		t.Errorf("expected site to be returned without waiting, got %v", diags)
+	}
+}
+
+func TestWaitForSiteTimeout(t *testing.T) {
+	for _, status := range []string{"CREATED", "DNS_ERROR", "OFFLINE"} {
+		t.Run(status, func(t *testing.T) {
+			r := testSiteWaitResource(t, status)
+			site := &ogosecurity.Site{DomainName: "foo.example.com", Status: "CREATED"}
+
+			// Created site is kept, so that it is not tainted
+			observed, diags := r.waitForSite(context.Background(), testSiteWaitFor(), time.Millisecond, site, true)
+			if diags.HasError() || diags.WarningsCount() != 1 {
+				t.Fatalf("expected a warning on creation timeout, got %v", diags)
+			}
+			if observed.Status != status {
+				t.Errorf("expected last observed status %s, got %s", status, observed.Status)
+			}
+			if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "status "+status) || !strings.Contains(detail, "status in [LE_CERT, CUST_CERT]") {
+				t.Errorf("expected warning to show conditions and last observed status %s, got %q", status, detail)
+			}
+
+			_, diags = r.waitForSite(context.Background(), testSiteWaitFor(), time.Millisecond, site, false)
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected an error on update timeout, got %v", diags)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "status "+status) {
+				t.Errorf("expected error to show last observed status %s, got %q", status, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// tlsOptionsDefaultMinVersion is the minimum TLS version accepted by default.
+const tlsOptionsDefaultMinVersion = "TLS_1.2"
+
+// tlsDeprecatedVersionNames are the TLS versions deprecated by RFC 8996.
+var tlsDeprecatedVersionNames = []string{"TLS_1.0", "TLS_1.1"}
+
+// tlsVersionNames are the supported TLS versions, in increasing order.
+var tlsVersionNames = []string{"TLS_1.0", "TLS_1.1", "TLS_1.2", "TLS_1.3"}
+
+// tlsCurveNames are the supported elliptic curves and key exchanges, by
+// their Go crypto/tls names.
+var tlsCurveNames = []string{"CurveP256", "CurveP384", "CurveP521", "X25519", "X25519MLKEM768"}
+
+// tlsCipherSuite is a cipher suite accepted by Ogo Shield.
+type tlsCipherSuite struct {
+	Name     string
+	Versions []string
+}
+
+// tlsCipherSuites are the cipher suites accepted by Ogo Shield for TLS 1.2
+// and earlier versions, by their IANA names, secure ones first. TLS 1.3
+// cipher suites can't be configured.
+var tlsCipherSuites = []tlsCipherSuite{
+	{Name: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_AES_128_GCM_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_AES_256_GCM_SHA384", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_AES_128_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_AES_256_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_AES_128_CBC_SHA256", Versions: []string{"TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_3DES_EDE_CBC_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_ECDSA_WITH_RC4_128_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_ECDHE_RSA_WITH_RC4_128_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+	{Name: "TLS_RSA_WITH_RC4_128_SHA", Versions: []string{"TLS_1.0", "TLS_1.1", "TLS_1.2"}},
+}
+
+// tlsCipherSuiteNames returns the names of supported cipher suites.
+func tlsCipherSuiteNames() []string {
+	names := []string{}
+	for _, suite := range tlsCipherSuites {
+		names = append(names, suite.Name)
+	}
+
+	return names
+}
+
+// tlsCipherSuiteVersions returns the TLS versions a cipher suite can be used
+// with, among versions.
+func tlsCipherSuiteVersions(name string, versions []string) []string {
+	i := slices.IndexFunc(tlsCipherSuites, func(suite tlsCipherSuite) bool { return suite.Name == name })
+	if i < 0 {
+		return nil
+	}
+
+	usable := []string{}
+	for _, version := range versions {
+		if slices.Contains(tlsCipherSuites[i].Versions, version) {
+			usable = append(usable, version)
+		}
+	}
+
+	return usable
+}
+
+// tlsVersionRange returns the TLS versions from minVersion to maxVersion.
+// Unset versions default to TLS_1.2 and TLS_1.3.
+func tlsVersionRange(minVersion string, maxVersion string) []string {
+	if minVersion == "" {
+		minVersion = tlsOptionsDefaultMinVersion
+	}
+	if maxVersion == "" {
+		maxVersion = "TLS_1.3"
+	}
+
+	first, last := slices.Index(tlsVersionNames, minVersion), slices.Index(tlsVersionNames, maxVersion)
+	if first < 0 || last < first {
+		return nil
+	}
+
+	return tlsVersionNames[first : last+1]
+}
+
+// tlsCipherSuitesDiagnostics warns about cipher suites which can't be used
+// with any of the accepted TLS versions, and returns an error when no cipher
+// suite is left for them. TLS 1.3 cipher suites are always enabled, so
+// that TLS 1.3 connections never lack a cipher suite.
+func tlsCipherSuitesDiagnostics(minVersion string, maxVersion string, cipherSuites []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	versions := tlsVersionRange(minVersion, maxVersion)
+	if len(versions) == 0 || len(cipherSuites) == 0 {
+		return diags
+	}
+
+	usable := 0
+	for i, name := range cipherSuites {
+		if len(tlsCipherSuiteVersions(name, tlsVersionNames)) == 0 {
+			// Unknown cipher suites are reported by attribute validators
+			return diags
+		}
+		if len(tlsCipherSuiteVersions(name, versions)) > 0 {
+			usable++
+			continue
+		}
+		diags.AddAttributeWarning(
+			path.Root("cipher_suites").AtListIndex(i),
+			"Unused cipher suite",
+			fmt.Sprintf("Cipher suite %s can only be used with %s, it is never used with accepted TLS versions %s.",
+				name, strings.Join(tlsCipherSuiteVersions(name, tlsVersionNames), ", "), strings.Join(versions, ", ")),
+		)
+	}
+
+	if usable == 0 && !slices.Contains(versions, "TLS_1.3") {
+		diags.AddAttributeError(
+			path.Root("cipher_suites"),
+			"No usable cipher suite",
+			fmt.Sprintf("None of the cipher suites can be used with accepted TLS versions %s, clients could never connect. "+
+				"Add cipher suites supported by these versions or change min_tls_version and max_tls_version.", strings.Join(versions, ", ")),
+		)
+	}
+
+	return diags
+}
+
+// tlsDeprecatedVersionsDiagnostics reports deprecated TLS versions accepted
+// from minVersion to maxVersion, as errors when strict is true, warnings
+// otherwise.
+func tlsDeprecatedVersionsDiagnostics(minVersion string, maxVersion string, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	deprecated := []string{}
+	for _, version := range tlsVersionRange(minVersion, maxVersion) {
+		if slices.Contains(tlsDeprecatedVersionNames, version) {
+			deprecated = append(deprecated, version)
+		}
+	}
+	if len(deprecated) > 0 {
+		diags.Append(validationDiagnostic(strict,
+			path.Root("min_tls_version"),
+			"Deprecated TLS version accepted",
+			fmt.Sprintf("TLS options accept %s, deprecated by RFC 8996 and not allowed by PCI DSS. "+
+				"Set min_tls_version to TLS_1.2 or later unless legacy clients must be supported.", strings.Join(deprecated, " and ")),
+		))
+	}
+
+	return diags
+}
@@ -0,0 +1,106 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTlsVersionRange(t *testing.T) {
+	for name, tc := range map[string]struct {
+		minVersion string
+		maxVersion string
+		expected   []string
+	}{
+		"defaults":          {expected: []string{"TLS_1.2", "TLS_1.3"}},
+		"legacy":            {minVersion: "TLS_1.0", maxVersion: "TLS_1.1", expected: []string{"TLS_1.0", "TLS_1.1"}},
+		"single version":    {minVersion: "TLS_1.3", expected: []string{"TLS_1.3"}},
+		"inverted versions": {minVersion: "TLS_1.3", maxVersion: "TLS_1.2", expected: nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := tlsVersionRange(tc.minVersion, tc.maxVersion); !slices.Equal(actual, tc.expected) {
+				t.Errorf("expected versions %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTlsCipherSuiteNames(t *testing.T) {
+	names := tlsCipherSuiteNames()
+	for _, name := range []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"} {
+		if slices.Contains(names, name) {
+			t.Errorf("expected TLS 1.3 cipher suite %s not to be configurable", name)
+		}
+	}
+	if !slices.Contains(names, "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256") {
+		t.Errorf("expected TLS 1.2 cipher suites to be configurable, got %v", names)
+	}
+}
+
+func TestTlsCipherSuitesDiagnostics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		minVersion   string
+		maxVersion   string
+		cipherSuites []string
+		warnings     int
+		errors       int
+	}{
+		"usable cipher suites": {
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		},
+		"unused cipher suite": {
+			minVersion:   "TLS_1.0",
+			maxVersion:   "TLS_1.1",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			warnings:     1,
+		},
+		"no usable cipher suite": {
+			minVersion:   "TLS_1.0",
+			maxVersion:   "TLS_1.1",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			warnings:     1,
+			errors:       1,
+		},
+		"TLS 1.3 cipher suites always accepted": {
+			minVersion:   "TLS_1.3",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			warnings:     1,
+		},
+		"unknown cipher suite": {
+			maxVersion:   "TLS_1.2",
+			cipherSuites: []string{"TLS_FOO"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := tlsCipherSuitesDiagnostics(tc.minVersion, tc.maxVersion, tc.cipherSuites)
+			if diags.WarningsCount() != tc.warnings || diags.ErrorsCount() != tc.errors {
+				t.Errorf("expected %d warnings and %d errors, got %v", tc.warnings, tc.errors, diags)
+			}
+		})
+	}
+}
+
+func TestTlsDeprecatedVersionsDiagnostics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		minVersion string
+		maxVersion string
+		strict     bool
+		warnings   int
+		errors     int
+	}{
+		"defaults":           {},
+		"TLS 1.0":            {minVersion: "TLS_1.0", warnings: 1},
+		"TLS 1.1 strict":     {minVersion: "TLS_1.1", strict: true, errors: 1},
+		"inverted versions":  {minVersion: "TLS_1.1", maxVersion: "TLS_1.0"},
+		"TLS 1.2 to TLS 1.3": {minVersion: "TLS_1.2", maxVersion: "TLS_1.3"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := tlsDeprecatedVersionsDiagnostics(tc.minVersion, tc.maxVersion, tc.strict)
+			if diags.WarningsCount() != tc.warnings || diags.ErrorsCount() != tc.errors {
+				t.Errorf("expected %d warnings and %d errors, got %v", tc.warnings, tc.errors, diags)
+			}
+		})
+	}
+}
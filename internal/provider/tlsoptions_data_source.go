@@ -33,6 +33,11 @@ type tlsoptionsModel struct {
 	ClientAuthCaCerts []types.String `tfsdk:"client_auth_ca_certs"`
 	MinTlsVersion     types.String   `tfsdk:"min_tls_version"`
 	MaxTlsVersion     types.String   `tfsdk:"max_tls_version"`
+	CipherSuites      []types.String `tfsdk:"cipher_suites"`
+	CurvePreferences  []types.String `tfsdk:"curve_preferences"`
+	SniStrict         types.Bool     `tfsdk:"sni_strict"`
+	AlpnProtocols     []types.String `tfsdk:"alpn_protocols"`
+	UsedBySites       []types.String `tfsdk:"used_by_sites"`
 }
 
 func NewTlsOptionsDataSource() datasource.DataSource {
@@ -81,6 +86,30 @@ func (d *tlsoptionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 							Computed:    true,
 							Description: "Maximum TLS version accepted.",
 						},
+						"cipher_suites": schema.ListAttribute{
+							Computed:    true,
+							Description: "Cipher suites accepted with TLS 1.2 and earlier versions, in order of preference. Null if Ogo Shield default cipher suites are used.",
+							ElementType: types.StringType,
+						},
+						"curve_preferences": schema.ListAttribute{
+							Computed:    true,
+							Description: "Elliptic curves used for key exchange, in order of preference. Null if Ogo Shield default curves are used.",
+							ElementType: types.StringType,
+						},
+						"sni_strict": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether TLS connections of clients which don't send a server name (SNI) matching a site domain name are rejected.",
+						},
+						"alpn_protocols": schema.ListAttribute{
+							Computed:    true,
+							Description: "Application protocols negotiated with ALPN, in order of preference. Null if Ogo Shield default protocols are used.",
+							ElementType: types.StringType,
+						},
+						"used_by_sites": schema.ListAttribute{
+							Computed:    true,
+							Description: "Domain names of sites using these TLS options, null if sites can't be listed. Sites are listed once per Terraform run.",
+							ElementType: types.StringType,
+						},
 					},
 				},
 			},
@@ -124,6 +153,15 @@ func (d *tlsoptionsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	// Sites using TLS options
+	sites, err := cachedTlsOptionsSites(d.client)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to list sites using TLS options",
+			"Could not list sites using TLS options, used_by_sites is null: "+err.Error(),
+		)
+	}
+
 	// Map response body to model
 	for _, t := range tlsoptions {
 		tlsoptionsState := tlsoptionsModel{
@@ -132,12 +170,20 @@ func (d *tlsoptionsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			ClientAuthType: types.StringValue(t.ClientAuthType),
 			MinTlsVersion:  types.StringPointerValue(t.MinTlsVersion),
 			MaxTlsVersion:  types.StringPointerValue(t.MaxTlsVersion),
+			SniStrict:      types.BoolValue(t.SniStrict != nil && *t.SniStrict),
 		}
 
 		for _, cert := range t.ClientAuthCaCerts {
 			tlsoptionsState.ClientAuthCaCerts = append(tlsoptionsState.ClientAuthCaCerts, types.StringValue(cert))
 		}
 
+		tlsoptionsState.CipherSuites = stringValuesOrNull(t.CipherSuites)
+		tlsoptionsState.CurvePreferences = stringValuesOrNull(t.CurvePreferences)
+		tlsoptionsState.AlpnProtocols = stringValuesOrNull(t.AlpnProtocols)
+		if sites != nil {
+			tlsoptionsState.UsedBySites = domainNameValues(sites[t.Uid])
+		}
+
 		state.TlsOptions = append(state.TlsOptions, tlsoptionsState)
 	}
 
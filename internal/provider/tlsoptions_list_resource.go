@@ -0,0 +1,119 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &tlsOptionsListResource{}
+	_ list.ListResourceWithConfigure = &tlsOptionsListResource{}
+)
+
+// NewTlsOptionsListResource is a helper function to simplify the provider implementation.
+func NewTlsOptionsListResource() list.ListResource {
+	return &tlsOptionsListResource{}
+}
+
+// tlsOptionsListResource is the list resource implementation.
+type tlsOptionsListResource struct {
+	client *ogosecurity.Client
+}
+
+// Metadata returns the list resource type name.
+func (r *tlsOptionsListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_tlsoptions"
+}
+
+// ListResourceConfigSchema defines the schema for the list resource config.
+func (r *tlsOptionsListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List Ogo Shield TLS Options of the organization.",
+	}
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *tlsOptionsListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ogosecurity.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected list resource configure type",
+			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// List streams all TLS options.
+func (r *tlsOptionsListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	allTlsOptions := r.client.AllTlsOptions()
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for tlsOptions, err := range allTlsOptions {
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError(
+					"Error listing Ogo TLS options",
+					"Could not list Ogo TLS options, unexpected error: "+err.Error(),
+				)
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			if !push(r.result(ctx, req, tlsOptions)) {
+				return
+			}
+
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+// result returns the list result of TLS options, with its full resource
+// object if requested.
+func (r *tlsOptionsListResource) result(ctx context.Context, req list.ListRequest, tlsOptions *ogosecurity.TlsOptions) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = tlsOptions.Name
+
+	identity := TlsOptionsResourceIdentityModel{
+		Organization: types.StringValue(r.client.Organization),
+		Uid:          types.StringValue(tlsOptions.Uid),
+	}
+	result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+	if req.IncludeResource {
+		state := TlsOptionsResourceModel{
+			Uid: types.StringValue(tlsOptions.Uid),
+		}
+		state.read(tlsOptions)
+		result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTlsOptionsListResource(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	organization := os.Getenv("OGO_ORGANIZATION")
+	if organization == "" {
+		t.Errorf("OGO_ORGANIZATION must be set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			// Create TLS options to list
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name            = "list foo bar"
+  min_tls_version = "TLS_1.2"
+}
+`,
+			},
+			// List TLS options
+			{
+				Query: true,
+				Config: providerConfig + `
+list "ogo_shield_tlsoptions" "all" {
+  provider         = ogo
+  include_resource = true
+}
+`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("ogo_shield_tlsoptions.all", 1),
+					querycheck.ExpectIdentity("ogo_shield_tlsoptions.all", map[string]knownvalue.Check{
+						"organization": knownvalue.StringExact(organization),
+						"uid":          knownvalue.NotNull(),
+					}),
+					querycheck.ExpectResourceKnownValues("ogo_shield_tlsoptions.all", queryfilter.ByDisplayName(knownvalue.StringExact("list foo bar")), []querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("min_tls_version"), KnownValue: knownvalue.StringExact("TLS_1.2")},
+						{Path: tfjsonpath.New("max_tls_version"), KnownValue: knownvalue.Null()},
+						{Path: tfjsonpath.New("uid"), KnownValue: knownvalue.NotNull()},
+					}),
+				},
+			},
+		},
+	})
+}
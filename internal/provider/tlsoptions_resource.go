@@ -6,15 +6,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	ogosecurity "terraform-provider-ogo/internal/ogo"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -23,20 +33,42 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &tlsOptionsResource{}
-	_ resource.ResourceWithConfigure   = &tlsOptionsResource{}
-	_ resource.ResourceWithImportState = &tlsOptionsResource{}
+	_ resource.Resource                   = &tlsOptionsResource{}
+	_ resource.ResourceWithConfigure      = &tlsOptionsResource{}
+	_ resource.ResourceWithImportState    = &tlsOptionsResource{}
+	_ resource.ResourceWithUpgradeState   = &tlsOptionsResource{}
+	_ resource.ResourceWithIdentity       = &tlsOptionsResource{}
+	_ resource.ResourceWithValidateConfig = &tlsOptionsResource{}
+	_ resource.ResourceWithModifyPlan     = &tlsOptionsResource{}
 )
 
+// tlsOptionsDefaultExpiryWarningDays is the default number of days before
+// expiry of certificate authorities to warn about.
+const tlsOptionsDefaultExpiryWarningDays = 30
+
 // TlsOptionsResourceModel maps the resource schema data.
 type TlsOptionsResourceModel struct {
-	Uid               types.String   `tfsdk:"uid"`
-	Name              types.String   `tfsdk:"name"`
-	ClientAuthType    types.String   `tfsdk:"client_auth_type"`
-	ClientAuthCaCerts []types.String `tfsdk:"client_auth_ca_certs"`
-	MinTlsVersion     types.String   `tfsdk:"min_tls_version"`
-	MaxTlsVersion     types.String   `tfsdk:"max_tls_version"`
-	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Uid               types.String          `tfsdk:"uid"`
+	Name              types.String          `tfsdk:"name"`
+	ClientAuthType    types.String          `tfsdk:"client_auth_type"`
+	ClientAuthCaCerts []pemCertificateValue `tfsdk:"client_auth_ca_certs"`
+	CaCertsInfo       []CaCertInfoModel     `tfsdk:"ca_certs_info"`
+	ExpiryWarningDays types.Int64           `tfsdk:"ca_certs_expiry_warning_days"`
+	MinTlsVersion     types.String          `tfsdk:"min_tls_version"`
+	MaxTlsVersion     types.String          `tfsdk:"max_tls_version"`
+	CipherSuites      []types.String        `tfsdk:"cipher_suites"`
+	CurvePreferences  []types.String        `tfsdk:"curve_preferences"`
+	SniStrict         types.Bool            `tfsdk:"sni_strict"`
+	AlpnProtocols     []types.String        `tfsdk:"alpn_protocols"`
+	ForceDetach       types.Bool            `tfsdk:"force_detach"`
+	UsedBySites       []types.String        `tfsdk:"used_by_sites"`
+	LastUpdated       types.String          `tfsdk:"last_updated"`
+}
+
+// TlsOptionsResourceIdentityModel maps the resource identity data.
+type TlsOptionsResourceIdentityModel struct {
+	Organization types.String `tfsdk:"organization"`
+	Uid          types.String `tfsdk:"uid"`
 }
 
 // NewTlsOptionsResource is a helper function to simplify the provider implementation.
@@ -46,7 +78,8 @@ func NewTlsOptionsResource() resource.Resource {
 
 // tlsOptionsResource is the resource implementation.
 type tlsOptionsResource struct {
-	client *ogosecurity.Client
+	client           *ogosecurity.Client
+	strictValidation bool
 }
 
 // Metadata returns the resource type name.
@@ -57,6 +90,7 @@ func (r *tlsOptionsResource) Metadata(_ context.Context, req resource.MetadataRe
 // Schema defines the schema for the resource.
 func (r *tlsOptionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: tlsOptionsResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -74,33 +108,121 @@ func (r *tlsOptionsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed: true,
 				Description: "Authentication type needed to authenticate clients. Supported values:\n" +
 					"  * **VerifyClientCertIfGiven**: If a certificate is provided, verify if it is signed by a CA listed in `client_auth_ca_certs`. Otherwise, proceed without any certificate.\n" +
-					"  * **RequireAndVerifyClientCert**: Require a certificate, which must be signed by a CA listed in `client_auth_ca_certs`.",
+					"  * **RequireAndVerifyClientCert**: Require a certificate, which must be signed by a CA listed in `client_auth_ca_certs`. `client_auth_ca_certs` can't be empty.",
 				Default: stringdefault.StaticString("VerifyClientCertIfGiven"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("VerifyClientCertIfGiven", "RequireAndVerifyClientCert"),
 				},
 			},
 			"client_auth_ca_certs": schema.SetAttribute{
-				Required:    true,
-				Description: "List of certificate authorities used to verify client certificates.",
-				ElementType: types.StringType,
+				Required: true,
+				Description: "List of PEM encoded certificate authorities used to verify client certificates. " +
+					"Certificates are checked at plan time and must be CA certificates.",
+				ElementType: pemCertificateType{},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(caCertificate()),
+				},
 			},
-			"min_tls_version": schema.StringAttribute{
-				Optional:    true,
+			"ca_certs_info": schema.SetNestedAttribute{
 				Computed:    true,
-				Description: "Minimum TLS version accepted. Supported values: **TLS_1.0**, **TLS_1.1**, **TLS_1.2**, **TLS_1.3**.",
-				Default:     stringdefault.StaticString("TLS_1.2"),
+				Description: "Information about certificate authorities of `client_auth_ca_certs`.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subject": schema.StringAttribute{
+							Computed:    true,
+							Description: "Distinguished name of the certificate authority.",
+						},
+						"fingerprint_sha256": schema.StringAttribute{
+							Computed:    true,
+							Description: "SHA-256 fingerprint of the certificate, in hexadecimal.",
+						},
+						"not_after": schema.StringAttribute{
+							Computed:    true,
+							Description: "Expiration date of the certificate, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+			"ca_certs_expiry_warning_days": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf("Number of days before expiry of a certificate authority of `client_auth_ca_certs` "+
+					"from which plans show a warning. Defaults to %d.", tlsOptionsDefaultExpiryWarningDays),
+				Default: int64default.StaticInt64(tlsOptionsDefaultExpiryWarningDays),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_tls_version": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Minimum TLS version accepted. Supported values: **TLS_1.0**, **TLS_1.1**, **TLS_1.2**, **TLS_1.3**. " +
+					"Deprecated versions TLS_1.0 and TLS_1.1 are reported by plans.",
+				Default: stringdefault.StaticString(tlsOptionsDefaultMinVersion),
 				Validators: []validator.String{
 					stringvalidator.OneOf("TLS_1.0", "TLS_1.1", "TLS_1.2", "TLS_1.3"),
 				},
 			},
 			"max_tls_version": schema.StringAttribute{
 				Optional:    true,
-				Description: "Maximum TLS version accepted. Supported values: **TLS_1.0**, **TLS_1.1**, **TLS_1.2**, **TLS_1.3**.",
+				Description: "Maximum TLS version accepted, can't be lower than `min_tls_version`. Supported values: **TLS_1.0**, **TLS_1.1**, **TLS_1.2**, **TLS_1.3**.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("TLS_1.0", "TLS_1.1", "TLS_1.2", "TLS_1.3"),
 				},
 			},
+			"cipher_suites": schema.ListAttribute{
+				Optional: true,
+				Description: "Cipher suites accepted with TLS 1.2 and earlier versions, in order of preference, by their IANA names " +
+					"(e.g. **TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256**). TLS 1.3 cipher suites can't be set, they are always accepted. " +
+					"Cipher suites must be usable with accepted TLS versions. Ogo Shield default cipher suites are used if not set.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(tlsCipherSuiteNames()...)),
+				},
+			},
+			"curve_preferences": schema.ListAttribute{
+				Optional:    true,
+				Description: "Elliptic curves used for key exchange, in order of preference. Ogo Shield default curves are used if not set.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(tlsCurveNames...)),
+				},
+			},
+			"sni_strict": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Reject TLS connections of clients which don't send a server name (SNI) matching a site domain name. " +
+					"If not set, the value set on Ogo is kept (default: **false**).",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"alpn_protocols": schema.ListAttribute{
+				Optional:    true,
+				Description: "Application protocols negotiated with ALPN, in order of preference (e.g. **h2**, **http/1.1**). Ogo Shield default protocols are used if not set.",
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 255)),
+				},
+			},
+			"force_detach": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Detach TLS options from sites using them before deleting them, sites then use Ogo Shield default TLS settings. " +
+					"Deletion fails listing these sites if false (default: **false**).",
+				Default: booldefault.StaticBool(false),
+			},
+			"used_by_sites": schema.SetAttribute{
+				Computed:    true,
+				Description: "Domain names of sites using these TLS options. Sites are listed once per Terraform run, and used_by_sites is kept unchanged if they can't be listed.",
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Last resource updated by Terraform.",
@@ -115,6 +237,30 @@ func (r *tlsOptionsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *tlsOptionsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Organization code of the TLS options, must match provider organization if set.",
+			},
+			"uid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UID of the TLS options.",
+			},
+		},
+	}
+}
+
+// identity returns the resource identity of TLS options.
+func (r *tlsOptionsResource) identity(uid types.String) TlsOptionsResourceIdentityModel {
+	return TlsOptionsResourceIdentityModel{
+		Organization: types.StringValue(r.client.Organization),
+		Uid:          uid,
+	}
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *tlsOptionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -123,18 +269,19 @@ func (r *tlsOptionsResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*ogosecurity.Client)
+	data, ok := req.ProviderData.(*ogoProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected resource configure type",
-			fmt.Sprintf("Expected *ogosecurity.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ogoProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.strictValidation = data.StrictValidation
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -154,6 +301,14 @@ func (r *tlsOptionsResource) Create(ctx context.Context, req resource.CreateRequ
 		MinTlsVersion:  plan.MinTlsVersion.ValueStringPointer(),
 		MaxTlsVersion:  plan.MaxTlsVersion.ValueStringPointer(),
 	}
+	if !plan.SniStrict.IsUnknown() {
+		t.SniStrict = plan.SniStrict.ValueBoolPointer()
+	}
+
+	// Cipher suites, curves and ALPN protocols
+	t.CipherSuites = stringValues(plan.CipherSuites)
+	t.CurvePreferences = stringValues(plan.CurvePreferences)
+	t.AlpnProtocols = stringValues(plan.AlpnProtocols)
 
 	// CA certificates
 	t.ClientAuthCaCerts = []string{}
@@ -173,11 +328,15 @@ func (r *tlsOptionsResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Map response body to schema and populate Computed attribute values
 	plan.Uid = types.StringValue(tlsOpt.Uid)
+	plan.SniStrict = types.BoolValue(tlsOpt.SniStrict != nil && *tlsOpt.SniStrict)
+	plan.UsedBySites = []types.String{}
+	plan.CaCertsInfo = caCertsInfo(plan.ClientAuthCaCerts)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(plan.Uid))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -204,25 +363,88 @@ func (r *tlsOptionsResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Overwrite properties with refreshed state
-	state.Name = types.StringValue(tlsOptions.Name)
-	state.ClientAuthType = types.StringValue(tlsOptions.ClientAuthType)
-	state.MinTlsVersion = types.StringPointerValue(tlsOptions.MinTlsVersion)
-	state.MaxTlsVersion = types.StringPointerValue(tlsOptions.MaxTlsVersion)
+	state.read(tlsOptions)
 
-	// CA certificates
-	state.ClientAuthCaCerts = []types.String{}
-	for _, cert := range tlsOptions.ClientAuthCaCerts {
-		state.ClientAuthCaCerts = append(state.ClientAuthCaCerts, types.StringValue(cert))
+	// Sites using TLS options
+	sites, err := cachedTlsOptionsSites(r.client)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("used_by_sites"),
+			"Unable to list sites using TLS options",
+			"Could not list sites using Ogo TLS options "+state.Uid.ValueString()+", used_by_sites is not refreshed: "+err.Error(),
+		)
+	} else {
+		state.UsedBySites = domainNameValues(sites[state.Uid.ValueString()])
 	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(state.Uid))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// read overwrites properties with TLS options values.
+func (m *TlsOptionsResourceModel) read(t *ogosecurity.TlsOptions) {
+	m.Name = types.StringValue(t.Name)
+	m.ClientAuthType = types.StringValue(t.ClientAuthType)
+	m.MinTlsVersion = types.StringPointerValue(t.MinTlsVersion)
+	m.MaxTlsVersion = types.StringNull()
+	if t.MaxTlsVersion != nil {
+		m.MaxTlsVersion = stringValueOrNull(*t.MaxTlsVersion)
+	}
+	m.SniStrict = types.BoolValue(t.SniStrict != nil && *t.SniStrict)
+
+	// Cipher suites, curves and ALPN protocols
+	m.CipherSuites = stringValuesOrNull(t.CipherSuites)
+	m.CurvePreferences = stringValuesOrNull(t.CurvePreferences)
+	m.AlpnProtocols = stringValuesOrNull(t.AlpnProtocols)
+
+	// CA certificates
+	m.ClientAuthCaCerts = []pemCertificateValue{}
+	for _, cert := range t.ClientAuthCaCerts {
+		m.ClientAuthCaCerts = append(m.ClientAuthCaCerts, pemCertificateStringValue(cert))
+	}
+	m.CaCertsInfo = caCertsInfo(m.ClientAuthCaCerts)
+	if m.ForceDetach.IsNull() {
+		m.ForceDetach = types.BoolValue(false)
+	}
+	if m.ExpiryWarningDays.IsNull() {
+		m.ExpiryWarningDays = types.Int64Value(tlsOptionsDefaultExpiryWarningDays)
+	}
+}
+
+// stringValues returns values as strings, null lists being returned as nil.
+func stringValues(values []types.String) []string {
+	if values == nil {
+		return nil
+	}
+
+	s := []string{}
+	for _, v := range values {
+		s = append(s, v.ValueString())
+	}
+
+	return s
+}
+
+// stringValuesOrNull returns strings as values, empty lists being returned
+// as null lists.
+func stringValuesOrNull(s []string) []types.String {
+	if len(s) == 0 {
+		return nil
+	}
+
+	values := []types.String{}
+	for _, v := range s {
+		values = append(values, types.StringValue(v))
+	}
+
+	return values
+}
+
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *tlsOptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
@@ -241,6 +463,14 @@ func (r *tlsOptionsResource) Update(ctx context.Context, req resource.UpdateRequ
 		MinTlsVersion:  plan.MinTlsVersion.ValueStringPointer(),
 		MaxTlsVersion:  plan.MaxTlsVersion.ValueStringPointer(),
 	}
+	if !plan.SniStrict.IsUnknown() {
+		t.SniStrict = plan.SniStrict.ValueBoolPointer()
+	}
+
+	// Cipher suites, curves and ALPN protocols
+	t.CipherSuites = stringValues(plan.CipherSuites)
+	t.CurvePreferences = stringValues(plan.CurvePreferences)
+	t.AlpnProtocols = stringValues(plan.AlpnProtocols)
 
 	// CA certificate
 	t.ClientAuthCaCerts = []string{}
@@ -259,11 +489,13 @@ func (r *tlsOptionsResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Map response body to schema and populate Computed attribute values
+	plan.CaCertsInfo = caCertsInfo(plan.ClientAuthCaCerts)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(plan.Uid))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -279,8 +511,36 @@ func (r *tlsOptionsResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	// Sites using TLS options are detached first if forced
+	sites, err := tlsOptionsSites(r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting TLS options",
+			"Could not list sites using TLS options, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if domainNames := sites[state.Uid.ValueString()]; len(domainNames) > 0 {
+		if !state.ForceDetach.ValueBool() {
+			resp.Diagnostics.AddError(
+				"TLS options in use",
+				fmt.Sprintf("TLS options %s are used by sites: %s. Change tlsoptions_uid of these sites, "+
+					"or set force_detach to true to detach them from sites before deletion.", state.Name.ValueString(), strings.Join(domainNames, ", ")),
+			)
+			return
+		}
+
+		if err := detachTlsOptions(r.client, state.Uid.ValueString(), domainNames); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting TLS options",
+				"Could not detach TLS options from sites, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Delete existing TLS options
-	err := r.client.DeleteTlsOptions(state.Uid.ValueString())
+	err = r.client.DeleteTlsOptions(state.Uid.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting TLS options",
@@ -290,7 +550,119 @@ func (r *tlsOptionsResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// ValidateConfig validates attributes consistency, and cipher suites against
+// accepted TLS versions.
+func (r *tlsOptionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var minVersion, maxVersion, clientAuthType types.String
+	var caCerts types.Set
+	var cipherSuites types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min_tls_version"), &minVersion)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_tls_version"), &maxVersion)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_auth_type"), &clientAuthType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_auth_ca_certs"), &caCerts)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cipher_suites"), &cipherSuites)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Client certificates can't be verified without certificate authorities
+	if clientAuthType.ValueString() == "RequireAndVerifyClientCert" && !caCerts.IsNull() && !caCerts.IsUnknown() && len(caCerts.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_auth_ca_certs"),
+			"Conflicting TLS options attributes",
+			"Attribute client_auth_ca_certs can't be empty when client_auth_type is RequireAndVerifyClientCert, "+
+				"as client certificates could never be verified and all clients would be rejected.",
+		)
+	}
+
+	if minVersion.IsUnknown() || maxVersion.IsUnknown() {
+		return
+	}
+
+	// Minimum TLS version can't be greater than maximum version
+	first := slices.Index(tlsVersionNames, minVersion.ValueString())
+	last := slices.Index(tlsVersionNames, maxVersion.ValueString())
+	if minVersion.IsNull() {
+		first = slices.Index(tlsVersionNames, tlsOptionsDefaultMinVersion)
+	}
+	if first >= 0 && last >= 0 && first > last {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_tls_version"),
+			"Conflicting TLS options attributes",
+			fmt.Sprintf("Attribute max_tls_version %s can't be lower than min_tls_version %s.", tlsVersionNames[last], tlsVersionNames[first]),
+		)
+		return
+	}
+
+	if !fullyKnown(ctx, cipherSuites) {
+		return
+	}
+
+	names := []string{}
+	resp.Diagnostics.Append(cipherSuites.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(tlsCipherSuitesDiagnostics(minVersion.ValueString(), maxVersion.ValueString(), names)...)
+}
+
+// ModifyPlan warns about deprecated TLS versions, sets information about
+// planned certificate authorities and warns about expired ones or expiring
+// soon.
+func (r *tlsOptionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on resource destruction
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Deprecated TLS versions
+	var minVersion, maxVersion types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("min_tls_version"), &minVersion)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_tls_version"), &maxVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !minVersion.IsUnknown() && !maxVersion.IsUnknown() {
+		resp.Diagnostics.Append(tlsDeprecatedVersionsDiagnostics(minVersion.ValueString(), maxVersion.ValueString(), r.strictValidation)...)
+	}
+
+	var caCerts types.Set
+	var days types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("client_auth_ca_certs"), &caCerts)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ca_certs_expiry_warning_days"), &days)...)
+	if resp.Diagnostics.HasError() || !fullyKnown(ctx, caCerts) {
+		return
+	}
+
+	pemCerts := []pemCertificateValue{}
+	resp.Diagnostics.Append(caCerts.ElementsAs(ctx, &pemCerts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ca_certs_info"), caCertsInfo(pemCerts))...)
+	if !days.IsUnknown() {
+		resp.Diagnostics.Append(caCertsExpiryDiagnostics(pemCerts, days.ValueInt64(), time.Now())...)
+	}
+}
+
 func (r *tlsOptionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import domain name and save to name attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("uid"), req, resp)
+	var uid string
+	if req.ID != "" {
+		// Import ID format is [<organization>/]<uid>
+		id, diags := parseOrganizationImportID(r.client, req.ID, "uid")
+		resp.Diagnostics.Append(diags...)
+		uid = id
+	} else {
+		// Import identity
+		var identity TlsOptionsResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		resp.Diagnostics.Append(checkImportOrganization(r.client, identity.Organization.ValueString())...)
+		uid = identity.Uid.ValueString()
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uid"), uid)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, r.identity(types.StringValue(uid)))...)
 }
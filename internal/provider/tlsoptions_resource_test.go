@@ -6,10 +6,16 @@ package provider
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func testAccImportStateIdFromAttribute(resourceName string, attributeName string) resource.ImportStateIdFunc {
@@ -107,6 +113,12 @@ EOT
 					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "client_auth_ca_certs.#", "2"),
 					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "client_auth_ca_certs.0", "-----BEGIN CERTIFICATE-----\nMIIDnTCCAoWgAwIBAgIUHvOpeMH+4Lk1ewQZKMwOygGBQe0wDQYJKoZIhvcNAQEL\nBQAwXjELMAkGA1UEBhMCRlIxDzANBgNVBAgMBkZyYW5jZTEOMAwGA1UEBwwFUGFy\naXMxFDASBgNVBAoMC09nb1NlY3VyaXR5MRgwFgYDVQQDDA9iYXIuZXhhbXBsZS5j\nb20wHhcNMjUwODEzMDYyODU1WhcNMzUwODExMDYyODU1WjBeMQswCQYDVQQGEwJG\nUjEPMA0GA1UECAwGRnJhbmNlMQ4wDAYDVQQHDAVQYXJpczEUMBIGA1UECgwLT2dv\nU2VjdXJpdHkxGDAWBgNVBAMMD2Jhci5leGFtcGxlLmNvbTCCASIwDQYJKoZIhvcN\nAQEBBQADggEPADCCAQoCggEBAO4dBU9DGbgBzjIYy/Qls0IglivSHyughVRa4nfZ\nb2b3iGP1rEa+xNlnmOlgxp8ihjxF4yBz/DMGVDEDnErwITUOxEG4fJ5gdX7a5Iyd\nOgYYyoh1RJKRkyWSGQoU4RmbVidTCyxq15j+yRBJDt3fll+Y9rlL+Ejl9QJCe+Zt\nkSab7pBn9SmUzX8IeHyX1IpEMA4nNtFI8ysNSZNxPJa1hB3tXtVGZrkhpecCZvx4\nIBpuRrjBSY3MaRE5YW51l7nC7jExC+IeNGe3mfKYUu0Re7fkK7n1auGmAJhTlzIR\n4126rTJDbZlKyDFSfoaDFsyYeNe2t2W6KlhG4d0dSiFwIucCAwEAAaNTMFEwHQYD\nVR0OBBYEFKBppFca57l7wutRyaIRZ3fwzZP7MB8GA1UdIwQYMBaAFKBppFca57l7\nwutRyaIRZ3fwzZP7MA8GA1UdEwEB/wQFMAMBAf8wDQYJKoZIhvcNAQELBQADggEB\nAGwIPOoZqd/3Uu3W2dUcd8HWw/VmjokjKrC811KUfhiijpFpQjGMcGQrjti3rIkk\n5ZQyItkw91/IaUPOnyO8H5O/I/4RmTPaqbhmZ2gn8Ekw3/TO79tBB3bQWcfaSkK9\nb+4+ryk2fCe3Um6Q/NCeSRwYe3Z8Xe5ByqJfjGmrXLyU//folGAtnx4uaAeJ98ze\njUXT17x8AbdEt2JIpYoJI7xFC8mOr0s3LvA/gFmpNkuRNbCNQF2v5Qt9L2AYT0Fv\nB5uT42VuHQvRRNReAxa5oNGp/zcCjspaouPia03Tf5ZNZEd5LUFANLHPtsJg4jBB\nkjHKjCnt0/9fttE1u/gMW7k=\n-----END CERTIFICATE-----\n"),
 					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "client_auth_ca_certs.1", "-----BEGIN CERTIFICATE-----\nMIIDnTCCAoWgAwIBAgIUU5bIl5SJavP6YWPL/RUPLCbGu9owDQYJKoZIhvcNAQEL\nBQAwXjELMAkGA1UEBhMCRlIxDzANBgNVBAgMBkZyYW5jZTEOMAwGA1UEBwwFUGFy\naXMxFDASBgNVBAoMC09nb1NlY3VyaXR5MRgwFgYDVQQDDA9mb28uZXhhbXBsZS5j\nb20wHhcNMjUwODEzMDYyODM5WhcNMzUwODExMDYyODM5WjBeMQswCQYDVQQGEwJG\nUjEPMA0GA1UECAwGRnJhbmNlMQ4wDAYDVQQHDAVQYXJpczEUMBIGA1UECgwLT2dv\nU2VjdXJpdHkxGDAWBgNVBAMMD2Zvby5leGFtcGxlLmNvbTCCASIwDQYJKoZIhvcN\nAQEBBQADggEPADCCAQoCggEBANZ0zrEH22IMXp8tQ5PbwLFHmCQRc1/T1ge5z7ho\np6zdyFn5GEFrMv1ZOywPBPlCz+Lb/5sWWj9qhcMw6JkPogKKVx9PQZDwfpc9ov+M\nmujh/SM1Ms07AFt286h9e0yZzQfP9t6B9+Dns4Lgn6/+Ua8g7VW+Hrq3V9Ait0bx\nkDOZUj0djOp9H3tShtgl8p9Z+dcqYIAPtkjSTt/U7jUDtR9PH6qz4/gXE/mCKq4e\nLf+63nLqGfZ3S1mIwjysRhPsJwy4g9v+E6fHO4Emfk4KF6EvFj3GVXyckxLbxKNa\n1yYRUhSLZvNCNyDVvySVUta7yOhdzyC53YvS/Emtrh/7I6kCAwEAAaNTMFEwHQYD\nVR0OBBYEFF3MC9L6J3lESlcdryXXFzC1uJGvMB8GA1UdIwQYMBaAFF3MC9L6J3lE\nSlcdryXXFzC1uJGvMA8GA1UdEwEB/wQFMAMBAf8wDQYJKoZIhvcNAQELBQADggEB\nABUa9KqjfCB5lf9G7rpVTqhrg5LIKzuYzH4c7MZ76R4GZyH475yV5jQYCj2Qr3Pq\n2m50UpEzVKICjfBSCbJulv5ZofSn8DWTpEBoLZA2pVM9yutI3wOQW350HX01nY82\n9j9im1yMVtdf1uAPd1O3pm+RUcSICI5YBFQ1/LAAEoSqrmSoVUPwH6pt9Gr+4E/w\nPpUcdAju8piy48Nx9ZD9vwCVjD67oRNnF00wEDJgrl8RpI9i0zOzflBxXyllGD8L\nXT6wvPmUpso+jn04qnizfMWaYy9P2ip8RgOslrH6WIe6GyXSy6VjAu9JSuVE5OYX\nXpYou5FLSGMhNaPTuaukAgY=\n-----END CERTIFICATE-----\n"),
+					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "ca_certs_info.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("ogo_shield_tlsoptions.test", "ca_certs_info.*", map[string]string{
+						"subject":   "CN=foo.example.com,O=OgoSecurity,L=Paris,ST=France,C=FR",
+						"not_after": "2035-08-11T06:28:39Z",
+					}),
+					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "ca_certs_expiry_warning_days", "30"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("ogo_shield_tlsoptions.test", "uid"),
 					resource.TestCheckResourceAttrSet("ogo_shield_tlsoptions.test", "last_updated"),
@@ -169,3 +181,254 @@ EOT
 		},
 	})
 }
+
+func TestAccTlsOptionsResourceImport(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	organization := os.Getenv("OGO_ORGANIZATION")
+	if organization == "" {
+		t.Errorf("OGO_ORGANIZATION must be set")
+	}
+
+	config := providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name = "mTLS import"
+  client_auth_ca_certs = [
+    <<-EOT
+-----BEGIN CERTIFICATE-----
+MIIDnTCCAoWgAwIBAgIUHvOpeMH+4Lk1ewQZKMwOygGBQe0wDQYJKoZIhvcNAQEL
+BQAwXjELMAkGA1UEBhMCRlIxDzANBgNVBAgMBkZyYW5jZTEOMAwGA1UEBwwFUGFy
+aXMxFDASBgNVBAoMC09nb1NlY3VyaXR5MRgwFgYDVQQDDA9iYXIuZXhhbXBsZS5j
+b20wHhcNMjUwODEzMDYyODU1WhcNMzUwODExMDYyODU1WjBeMQswCQYDVQQGEwJG
+UjEPMA0GA1UECAwGRnJhbmNlMQ4wDAYDVQQHDAVQYXJpczEUMBIGA1UECgwLT2dv
+U2VjdXJpdHkxGDAWBgNVBAMMD2Jhci5leGFtcGxlLmNvbTCCASIwDQYJKoZIhvcN
+AQEBBQADggEPADCCAQoCggEBAO4dBU9DGbgBzjIYy/Qls0IglivSHyughVRa4nfZ
+b2b3iGP1rEa+xNlnmOlgxp8ihjxF4yBz/DMGVDEDnErwITUOxEG4fJ5gdX7a5Iyd
+OgYYyoh1RJKRkyWSGQoU4RmbVidTCyxq15j+yRBJDt3fll+Y9rlL+Ejl9QJCe+Zt
+kSab7pBn9SmUzX8IeHyX1IpEMA4nNtFI8ysNSZNxPJa1hB3tXtVGZrkhpecCZvx4
+IBpuRrjBSY3MaRE5YW51l7nC7jExC+IeNGe3mfKYUu0Re7fkK7n1auGmAJhTlzIR
+4126rTJDbZlKyDFSfoaDFsyYeNe2t2W6KlhG4d0dSiFwIucCAwEAAaNTMFEwHQYD
+VR0OBBYEFKBppFca57l7wutRyaIRZ3fwzZP7MB8GA1UdIwQYMBaAFKBppFca57l7
+wutRyaIRZ3fwzZP7MA8GA1UdEwEB/wQFMAMBAf8wDQYJKoZIhvcNAQELBQADggEB
+AGwIPOoZqd/3Uu3W2dUcd8HWw/VmjokjKrC811KUfhiijpFpQjGMcGQrjti3rIkk
+5ZQyItkw91/IaUPOnyO8H5O/I/4RmTPaqbhmZ2gn8Ekw3/TO79tBB3bQWcfaSkK9
+b+4+ryk2fCe3Um6Q/NCeSRwYe3Z8Xe5ByqJfjGmrXLyU//folGAtnx4uaAeJ98ze
+jUXT17x8AbdEt2JIpYoJI7xFC8mOr0s3LvA/gFmpNkuRNbCNQF2v5Qt9L2AYT0Fv
+B5uT42VuHQvRRNReAxa5oNGp/zcCjspaouPia03Tf5ZNZEd5LUFANLHPtsJg4jBB
+kjHKjCnt0/9fttE1u/gMW7k=
+-----END CERTIFICATE-----
+EOT
+  ]
+}
+`
+
+	uid := testAccImportStateIdFromAttribute("ogo_shield_tlsoptions.test", "uid")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("ogo_shield_tlsoptions.test", map[string]knownvalue.Check{
+						"organization": knownvalue.StringExact(organization),
+						"uid":          knownvalue.NotNull(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState("ogo_shield_tlsoptions.test", tfjsonpath.New("uid")),
+				},
+			},
+			// Refresh doesn't plan any change
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// Import with organization in ID
+			{
+				ResourceName: "ogo_shield_tlsoptions.test",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					id, err := uid(s)
+					return organization + "/" + id, err
+				},
+				ImportStateVerifyIdentifierAttribute: "uid",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			// Import block followed by plan is empty
+			{
+				Config:            config,
+				ResourceName:      "ogo_shield_tlsoptions.test",
+				ImportStateIdFunc: uid,
+				ImportStateKind:   resource.ImportBlockWithID,
+				ImportState:       true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ogo_shield_tlsoptions.test", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			// Import block with resource identity
+			{
+				Config:          config,
+				ResourceName:    "ogo_shield_tlsoptions.test",
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				ImportState:     true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTlsOptionsResourceCipherSuites(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "PCI"
+  client_auth_ca_certs = []
+  cipher_suites        = ["TLS_ECDHE_RSA_WITH_RC5_SHA"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "PCI"
+  client_auth_ca_certs = []
+  min_tls_version      = "TLS_1.0"
+  max_tls_version      = "TLS_1.1"
+  cipher_suites        = ["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`No usable cipher suite`),
+			},
+		},
+	})
+}
+
+func TestAccTlsOptionsResourceInvalidCaCerts(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name = "mTLS truncated"
+  client_auth_ca_certs = [
+    <<-EOT
+-----BEGIN CERTIFICATE-----
+MIIDnTCCAoWgAwIBAgIUU5bIl5SJavP6YWPL/RUPLCbGu9owDQYJKoZIhvcNAQEL
+EOT
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid CA certificate`),
+			},
+		},
+	})
+}
+
+func TestAccTlsOptionsResourceInvalidConfig(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "Inverted versions"
+  client_auth_ca_certs = []
+  min_tls_version      = "TLS_1.3"
+  max_tls_version      = "TLS_1.2"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`max_tls_version TLS_1.2 can't be lower than min_tls_version TLS_1.3`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "Inverted default versions"
+  client_auth_ca_certs = []
+  max_tls_version      = "TLS_1.1"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`max_tls_version TLS_1.1 can't be lower than min_tls_version TLS_1.2`),
+			},
+			{
+				Config: providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "mTLS without CA"
+  client_auth_type     = "RequireAndVerifyClientCert"
+  client_auth_ca_certs = []
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`client_auth_ca_certs can't be empty when client_auth_type is`),
+			},
+		},
+	})
+}
+
+func TestAccTlsOptionsResourceUsedBySites(t *testing.T) {
+	providerConfig := testAccProviderConfig()
+
+	clusterUid := os.Getenv("OGO_CLUSTER_UID")
+	if clusterUid == "" {
+		t.Errorf("OGO_CLUSTER_UID must be set")
+	}
+
+	config := providerConfig + `
+resource "ogo_shield_tlsoptions" "test" {
+  name                 = "Used by foo"
+  client_auth_ca_certs = []
+  force_detach         = true
+}
+
+resource "ogo_shield_site" "foo" {
+  domain_name    = "foo.example.com"
+  cluster_uid    = "` + clusterUid + `"
+  origin_server  = "172.18.1.12"
+  tlsoptions_uid = ogo_shield_tlsoptions.test.uid
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "force_detach", "true"),
+					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "used_by_sites.#", "0"),
+				),
+			},
+			// Sites using TLS options are read on refresh
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ogo_shield_tlsoptions.test", "used_by_sites.#", "1"),
+					resource.TestCheckTypeSetElemAttr("ogo_shield_tlsoptions.test", "used_by_sites.*", "foo.example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
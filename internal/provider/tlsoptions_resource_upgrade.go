@@ -0,0 +1,91 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tlsOptionsResourceSchemaVersion is the current version of the TLS options
+// resource schema. Each prior version is frozen below with its own schema and
+// model, and upgraded step by step to the current version.
+const tlsOptionsResourceSchemaVersion = 1
+
+// tlsOptionsResourceModelV0 maps the resource schema data of version 0.
+type tlsOptionsResourceModelV0 struct {
+	Uid               types.String   `tfsdk:"uid"`
+	Name              types.String   `tfsdk:"name"`
+	ClientAuthType    types.String   `tfsdk:"client_auth_type"`
+	ClientAuthCaCerts []types.String `tfsdk:"client_auth_ca_certs"`
+	MinTlsVersion     types.String   `tfsdk:"min_tls_version"`
+	MaxTlsVersion     types.String   `tfsdk:"max_tls_version"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+}
+
+// tlsOptionsResourceSchemaV0 returns the resource schema of version 0.
+func tlsOptionsResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":             schema.StringAttribute{Required: true},
+			"uid":              schema.StringAttribute{Computed: true},
+			"client_auth_type": schema.StringAttribute{Optional: true, Computed: true},
+			"client_auth_ca_certs": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"min_tls_version": schema.StringAttribute{Optional: true, Computed: true},
+			"max_tls_version": schema.StringAttribute{Optional: true},
+			"last_updated":    schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// UpgradeState upgrades prior versions of the resource state to the current
+// version.
+func (r *tlsOptionsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := tlsOptionsResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var stateV0 tlsOptionsResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &stateV0)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, stateV0.upgrade())...)
+			},
+		},
+	}
+}
+
+// upgrade converts version 0 state to the current version. Attributes added
+// since version 0 are set to their defaults or computed from certificates,
+// other ones are read from Ogo on next refresh.
+func (m tlsOptionsResourceModelV0) upgrade() TlsOptionsResourceModel {
+	clientAuthCaCerts := []pemCertificateValue{}
+	for _, cert := range m.ClientAuthCaCerts {
+		clientAuthCaCerts = append(clientAuthCaCerts, pemCertificateValue{StringValue: cert})
+	}
+
+	return TlsOptionsResourceModel{
+		Uid:               m.Uid,
+		Name:              m.Name,
+		ClientAuthType:    m.ClientAuthType,
+		ClientAuthCaCerts: clientAuthCaCerts,
+		CaCertsInfo:       caCertsInfo(clientAuthCaCerts),
+		ExpiryWarningDays: types.Int64Value(tlsOptionsDefaultExpiryWarningDays),
+		MinTlsVersion:     m.MinTlsVersion,
+		MaxTlsVersion:     m.MaxTlsVersion,
+		SniStrict:         types.BoolNull(),
+		ForceDetach:       types.BoolValue(false),
+		LastUpdated:       m.LastUpdated,
+	}
+}
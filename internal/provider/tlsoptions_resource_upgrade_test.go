@@ -0,0 +1,14 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestTlsOptionsResourceUpgradeState(t *testing.T) {
+	for version := int64(0); version < tlsOptionsResourceSchemaVersion; version++ {
+		testUpgradeResourceState(t, "ogo_shield_tlsoptions", version, tlsOptionsResourceSchemaVersion)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tlsOptionsSites returns domain names of sites, sorted, by UID of the TLS
+// options they use.
+func tlsOptionsSites(client *ogosecurity.Client) (map[string][]string, error) {
+	sites := client.AllSites()
+
+	domainNames := map[string][]string{}
+	for site, err := range sites {
+		if err != nil {
+			return nil, err
+		}
+		if site.TlsOptions != nil && site.TlsOptions.Uid != "" {
+			domainNames[site.TlsOptions.Uid] = append(domainNames[site.TlsOptions.Uid], site.DomainName)
+		}
+	}
+	for _, names := range domainNames {
+		sort.Strings(names)
+	}
+
+	return domainNames, nil
+}
+
+// tlsOptionsSitesIndex is the index of sites by TLS options UID, listed once
+// per provider run.
+type tlsOptionsSitesIndex struct {
+	once  sync.Once
+	sites map[string][]string
+	err   error
+}
+
+// tlsOptionsSitesIndexes holds site indexes by client, so that sites are
+// listed once per provider configuration rather than once per TLS options.
+var tlsOptionsSitesIndexes sync.Map
+
+// cachedTlsOptionsSites returns domain names of sites by UID of the TLS
+// options they use, like tlsOptionsSites, sites being listed on first call
+// only. It is meant for reads, deletions must list sites again.
+func cachedTlsOptionsSites(client *ogosecurity.Client) (map[string][]string, error) {
+	value, _ := tlsOptionsSitesIndexes.LoadOrStore(client, &tlsOptionsSitesIndex{})
+	index := value.(*tlsOptionsSitesIndex)
+	index.once.Do(func() {
+		index.sites, index.err = tlsOptionsSites(client)
+	})
+
+	return index.sites, index.err
+}
+
+// detachTlsOptions removes TLS options from sites still using them, which
+// then use Ogo Shield default TLS settings. Only TLS options of sites are
+// updated, sites being locked so that concurrent updates are not lost.
+func detachTlsOptions(client *ogosecurity.Client, tlsOptionsUid string, domainNames []string) error {
+	for _, domainName := range domainNames {
+		if err := detachSiteTlsOptions(client, tlsOptionsUid, domainName); err != nil {
+			return fmt.Errorf("site %s: %w", domainName, err)
+		}
+	}
+
+	return nil
+}
+
+// detachSiteTlsOptions removes TLS options from site if it still uses them.
+func detachSiteTlsOptions(client *ogosecurity.Client, tlsOptionsUid string, domainName string) error {
+	defer lockSite(domainName)()
+
+	site, err := client.GetSite(domainName)
+	if err != nil {
+		return err
+	}
+	if site.TlsOptions == nil || site.TlsOptions.Uid != tlsOptionsUid {
+		return nil
+	}
+
+	var none *ogosecurity.TlsOptions
+	_, err = client.PatchSite(domainName, ogosecurity.SitePatch{TlsOptions: &none})
+
+	return err
+}
+
+// domainNameValues returns domain names as values, never nil so that they
+// are read as an empty set.
+func domainNameValues(domainNames []string) []types.String {
+	values := []types.String{}
+	for _, domainName := range domainNames {
+		values = append(values, types.StringValue(domainName))
+	}
+
+	return values
+}
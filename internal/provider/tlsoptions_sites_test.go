@@ -0,0 +1,131 @@
+// Copyright (c) OGO Security, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	ogosecurity "terraform-provider-ogo/internal/ogo"
+)
+
+const testTlsOptionsSites = `{
+  "content": [
+    {"domainName": "foo.example.com", "tlsOptions": {"uid": "b1f3c2d4"}},
+    {"domainName": "bar.example.com"},
+    {"domainName": "baz.example.com", "tlsOptions": {"uid": "b1f3c2d4"}}
+  ],
+  "totalElements": 3
+}`
+
+// testTlsOptionsSitesClient returns a client of an API serving sites, and
+// the payloads of site updates by domain name.
+func testTlsOptionsSitesClient(t *testing.T) (*ogosecurity.Client, map[string]map[string]any) {
+	t.Helper()
+
+	updates := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/organizations/org/sites":
+			_, _ = w.Write([]byte(testTlsOptionsSites))
+		case r.URL.Path == "/v2/organizations/org/sites/foo.example.com" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"domainName": "foo.example.com", "originServer": "172.18.1.10", "tlsOptions": {"uid": "b1f3c2d4"}}`))
+		case r.URL.Path == "/v2/organizations/org/sites/bar.example.com" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"domainName": "bar.example.com", "originServer": "172.18.1.11", "tlsOptions": {"uid": "c2d4e5f6"}}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/organizations/org/sites/") && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			update := map[string]any{}
+			_ = json.Unmarshal(body, &update)
+			updates[strings.TrimPrefix(r.URL.Path, "/v2/organizations/org/sites/")] = update
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, email, apikey, organization := server.URL, "user@example.com", "key", "org"
+	client, err := ogosecurity.NewClient(&endpoint, &email, &apikey, &organization)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return client, updates
+}
+
+func TestTlsOptionsSites(t *testing.T) {
+	client, _ := testTlsOptionsSitesClient(t)
+
+	sites, err := tlsOptionsSites(client)
+	if err != nil {
+		t.Fatalf("unexpected error listing sites: %s", err)
+	}
+
+	expected := []string{"baz.example.com", "foo.example.com"}
+	if actual := sites["b1f3c2d4"]; !slices.Equal(actual, expected) {
+		t.Errorf("expected sites %v, got %v", expected, actual)
+	}
+	if len(sites) != 1 {
+		t.Errorf("expected sites of 1 TLS options, got %v", sites)
+	}
+}
+
+func TestCachedTlsOptionsSites(t *testing.T) {
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		_, _ = w.Write([]byte(testTlsOptionsSites))
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint, email, apikey, organization := server.URL, "user@example.com", "key", "org"
+	client, err := ogosecurity.NewClient(&endpoint, &email, &apikey, &organization)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	for range 3 {
+		sites, err := cachedTlsOptionsSites(client)
+		if err != nil {
+			t.Fatalf("unexpected error listing sites: %s", err)
+		}
+		if len(sites["b1f3c2d4"]) != 2 {
+			t.Errorf("expected 2 sites using TLS options, got %v", sites)
+		}
+	}
+	if lists != 1 {
+		t.Errorf("expected sites to be listed once, got %d", lists)
+	}
+}
+
+func TestDetachTlsOptions(t *testing.T) {
+	client, updates := testTlsOptionsSitesClient(t)
+
+	if err := detachTlsOptions(client, "b1f3c2d4", []string{"foo.example.com", "bar.example.com"}); err != nil {
+		t.Fatalf("unexpected error detaching TLS options: %s", err)
+	}
+
+	update, ok := updates["foo.example.com"]
+	if !ok {
+		t.Fatalf("expected site foo.example.com to be updated")
+	}
+	if tlsOptions, ok := update["tlsOptions"]; !ok || tlsOptions != nil {
+		t.Errorf("expected TLS options to be removed, got %v", update["tlsOptions"])
+	}
+	if len(update) != 1 {
+		t.Errorf("expected only TLS options to be updated, got %v", update)
+	}
+	if _, ok := updates["bar.example.com"]; ok {
+		t.Errorf("expected site bar.example.com using other TLS options not to be updated")
+	}
+
+	if err := detachTlsOptions(client, "b1f3c2d4", []string{"unknown.example.com"}); err == nil {
+		t.Errorf("expected error detaching TLS options from unknown site")
+	}
+}
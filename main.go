@@ -6,8 +6,13 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
+	"terraform-provider-ogo/internal/export"
+	ogosecurity "terraform-provider-ogo/internal/ogo"
 	"terraform-provider-ogo/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -23,11 +28,20 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := exportCommand(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// Provider is only served over protocol version 6, nested attributes
+	// used in schemas can't be converted to protocol version 5.
 	opts := providerserver.ServeOpts{
 		Address: "ogosecurity.com/ogosecurity/ogo",
 		Debug:   debug,
@@ -39,3 +53,68 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// exportCommand writes Terraform configuration with import blocks for all
+// sites and TLS options of an organization. Authentication settings default
+// to the provider environment variables.
+func exportCommand(args []string) error {
+	var endpoint, email, apikey, organization, output string
+	var options export.Options
+	var overwrite bool
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Generate Terraform configuration and import blocks for existing sites and TLS options.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&endpoint, "endpoint", "", "Ogo Dashboard API endpoint (default OGO_ENDPOINT environment variable)")
+	flags.StringVar(&email, "email", "", "email address used to authenticate (default OGO_EMAIL environment variable)")
+	flags.StringVar(&apikey, "apikey", "", "API key used to authenticate (default OGO_APIKEY environment variable)")
+	flags.StringVar(&organization, "organization", "", "organization to export (default OGO_ORGANIZATION environment variable)")
+	flags.StringVar(&output, "output", ".", "directory where configuration files are written")
+	flags.BoolVar(&options.SplitByTag, "split-by-tag", false, "write sites in one file per tag, sites_<tag>.tf")
+	flags.BoolVar(&overwrite, "overwrite", false, "overwrite existing configuration files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value *string
+	}{
+		{"endpoint", &endpoint},
+		{"email", &email},
+		{"apikey", &apikey},
+		{"organization", &organization},
+	} {
+		env := "OGO_" + strings.ToUpper(setting.name)
+		if *setting.value == "" {
+			*setting.value = os.Getenv(env)
+		}
+		if *setting.value == "" {
+			return fmt.Errorf("missing or empty %s, set -%s option or %s environment variable", setting.name, setting.name, env)
+		}
+	}
+
+	client, err := ogosecurity.NewClient(&endpoint, &email, &apikey, &organization)
+	if err != nil {
+		return err
+	}
+
+	files, err := export.Generate(client, options)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+
+	paths, err := export.Write(output, files, overwrite)
+	for _, path := range paths {
+		fmt.Println("Wrote " + path)
+	}
+
+	return err
+}